@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Validate 验证结构体,可直接作为 Echo 的 e.Validator 使用
+func (v *Validator) Validate(i interface{}) error {
+	return v.ValidateStruct(i)
+}
+
+// ValidateStruct 验证结构体,与 Gin 的 binding.StructValidator 一致
+// 非结构体返回 nil,切片、数组依次验证每个元素,错误路径包含元素下标 eg: [1].name
+func (v *Validator) ValidateStruct(obj interface{}) error {
+	if obj == nil {
+		return nil
+	}
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.CanAddr() {
+			return v.Binding(value.Addr().Interface()).Error()
+		}
+		return v.Binding(value.Interface()).Error()
+	case reflect.Slice, reflect.Array:
+		var errs ValidationErrors
+		for i := 0; i < value.Len(); i++ {
+			err := v.ValidateStruct(value.Index(i).Interface())
+			if err == nil {
+				continue
+			}
+			var ve ValidationErrors
+			if !errors.As(err, &ve) {
+				return err
+			}
+			for _, fe := range ve {
+				fe.Path, fe.StructPath = joinPath(indexPath(blank, i), fe.Path), joinPath(indexPath(blank, i), fe.StructPath)
+			}
+			errs = append(errs, ve...)
+			if !v.GetConfig().CollectAll {
+				break
+			}
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+	}
+	return nil
+}
+
+// Engine 底层验证器,与 Gin 的 binding.StructValidator 一致,返回 Validator 本身
+func (v *Validator) Engine() interface{} {
+	return v
+}
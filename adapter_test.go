@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+type bindingForm struct {
+	Name  string `json:"name" binding:"required" desc:"姓名"`
+	Phone string `json:"phone" binding:"required,len=11" validate:"-" desc:"手机号"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	v := New()
+	for _, obj := range []interface{}{nil, 1, "a", (*collectForm)(nil), map[string]int{"a": 1}} {
+		if err := v.ValidateStruct(obj); err != nil {
+			t.Fatalf("ValidateStruct(%#v) = %v", obj, err)
+		}
+	}
+	if v.Engine() != v {
+		t.Fatal("Engine should return the validator itself")
+	}
+
+	form := collectForm{Name: "a", Age: 1, Email: "a@b.c"}
+	err := v.ValidateStruct(form)
+	if err == nil || err.Error() != "addresses为必填字段" {
+		t.Fatalf("ValidateStruct(struct) = %v", err)
+	}
+	if err := v.Validate(&form); err == nil || err.Error() != "addresses为必填字段" {
+		t.Fatalf("Validate(ptr) = %v", err)
+	}
+}
+
+func TestValidateStructSlice(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	items := []*bindingForm{{Name: "a", Phone: "1"}, {}}
+	v.GetConfig().ValidationTag = "binding"
+	err := v.ValidateStruct(&items)
+	var ve ValidationErrors
+	if !errors.As(err, &ve) || len(ve) != 3 {
+		t.Fatalf("ValidateStruct(slice) = %v", err)
+	}
+	want := [][3]string{{"[0].phone", "[0].Phone", "len"}, {"[1].name", "[1].Name", "required"}, {"[1].phone", "[1].Phone", "required"}}
+	for i, fe := range ve {
+		if got := [3]string{fe.Path, fe.StructPath, fe.Tag}; got != want[i] {
+			t.Fatalf("errors[%d] = %v, want %v", i, got, want[i])
+		}
+	}
+	if err.Error() != "手机号长度必须是11个字符; 姓名为必填字段; 手机号为必填字段" {
+		t.Fatalf("error = %v", err)
+	}
+
+	// 未开启 CollectAll 时返回第一个元素的错误
+	v.GetConfig().CollectAll = false
+	if err := v.ValidateStruct([2]bindingForm{{Name: "a", Phone: "12345678901"}, {}}); err == nil || err.Error() != "姓名为必填字段" {
+		t.Fatalf("ValidateStruct(array) = %v", err)
+	}
+	v.GetConfig().ValidationTag = defaultValidationTag
+	if err := v.ValidateStruct(items); err != nil {
+		t.Fatalf("ValidateStruct(validate tag) = %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// cacheKey 结构体验证规则缓存key,配置的tag名称不同时分别缓存
+type cacheKey struct {
+	typ              reflect.Type
+	validationTag    string
+	fieldDescribeTag string
+	omitemptyTag     string
+	scene            string
+}
+
+// cStruct 预编译的结构体验证规则
+type cStruct struct {
+	fields []*cField
+}
+
+// cField 预编译的字段验证规则
+type cField struct {
+	idx       int
+	name      string
+	jsonName  string
+	inline    bool   //匿名嵌入且未设置json标签,json路径与上级一致
+	alias     string //desc 别名,未设置时为 json 标签名
+	formName  string //form 标签名,未设置时为 json 标签名,表单请求时作为字段路径
+	formAlias string //表单请求时的别名,未设置 desc 时为 form 标签名
+	sf        reflect.StructField
+	rules     *cRules //字段验证规则
+	err       error   //tag 解析错误,验证时返回
+	partial   bool    //未设置验证标签的嵌套字段,只在 StructPartial 指定其下级字段时遍历
+}
+
+// cRules 一组验证规则,dive 之后的规则作用于切片、数组、map 的每个元素
+type cRules struct {
+	tags []*cTag //按 tagSeparator 分隔的验证规则
+	keys *cRules //dive,keys ... endkeys 之间的规则,作用于 map 的每个 key
+	dive *cRules //dive 之后的规则,作用于每个元素
+}
+
+// cTag 按 tagSeparator 分隔的单个验证规则
+type cTag struct {
+	isOmitempty   bool
+	isConditional bool     //条件验证 eg: required_if,字段为空时也需要验证
+	skipWhenEmpty bool     //条件必填,验证通过且字段为空时跳过后续验证
+	raw           string   //原始tag eg: email|len=11
+	ors           []*cRule //按 orSeparator 分隔的验证方法,任一通过即验证通过
+}
+
+// cRule 单个验证方法及预解析的参数
+type cRule struct {
+	tag   string
+	param string
+	fn    Func
+	p     *tagParam
+}
+
+// tagParam 预解析的 tag 参数,避免每次验证重复转换
+// 解析失败时保存错误,在验证方法实际使用该参数时 panic,与未缓存时行为一致
+type tagParam struct {
+	int64Val    int64
+	int64Err    error
+	durationVal int64
+	durationErr error
+	uint64Val   uint64
+	uint64Err   error
+	floatVal    float64
+	floatErr    error
+	boolVal     bool
+	boolErr     error
+	oneOfVals   []string
+}
+
+// newTagParam 预解析 tag 参数
+func newTagParam(param string) *tagParam {
+	p := &tagParam{}
+	p.int64Val, p.int64Err = strconv.ParseInt(param, 0, 64)
+	if d, err := time.ParseDuration(param); err == nil {
+		p.durationVal = int64(d)
+	} else {
+		p.durationVal, p.durationErr = p.int64Val, p.int64Err
+	}
+	p.uint64Val, p.uint64Err = strconv.ParseUint(param, 0, 64)
+	p.floatVal, p.floatErr = strconv.ParseFloat(param, 64)
+	p.boolVal, p.boolErr = strconv.ParseBool(param)
+	p.oneOfVals = splitOneOfParam(param)
+	return p
+}
+
+// extractStructCache 获取结构体验证规则,未缓存时解析并缓存,不同场景分别缓存
+func (v *Validator) extractStructCache(t reflect.Type, scene string) *cStruct {
+	conf := v.GetConfig()
+	key := cacheKey{
+		typ:              t,
+		validationTag:    conf.ValidationTag,
+		fieldDescribeTag: conf.FieldDescribeTag,
+		omitemptyTag:     conf.OmitemptyTag,
+		scene:            scene,
+	}
+	if cs, ok := v.structCache.Load(key); ok {
+		return cs.(*cStruct)
+	}
+	cs, _ := v.structCache.LoadOrStore(key, v.parseStruct(t, conf, scene))
+	return cs.(*cStruct)
+}
+
+// clearStructCache 清空结构体验证规则缓存,注册验证方法后调用
+func (v *Validator) clearStructCache() {
+	v.structCache.Range(func(key, _ interface{}) bool {
+		v.structCache.Delete(key)
+		return true
+	})
+	atomic.StoreInt32(&v.ruleCacheSize, 0)
+}
+
+// parseStruct 解析结构体全部字段在指定场景下的验证规则
+func (v *Validator) parseStruct(t reflect.Type, conf *Config, scene string) *cStruct {
+	cs := &cStruct{}
+	numFields := t.NumField()
+	for i := 0; i < numFields; i++ {
+		sf := t.Field(i)
+		// 是否空字段"-", struct{-}
+		if !sf.Anonymous && sf.PkgPath != blank {
+			continue
+		}
+		// 获取验证标签
+		validateTag := sf.Tag.Get(conf.ValidationTag)
+		// 验证标签是否忽略
+		if validateTag == skipValidationTag {
+			continue
+		}
+		// 过滤其他场景的验证规则
+		var tags []string
+		if validateTag != blank {
+			tags = filterSceneTags(strings.Split(validateTag, tagSeparator), scene)
+		}
+		// 是否遍历按过滤场景前的验证标签判断,当前场景没有验证规则时仍遍历下级字段
+		// 验证标签为空时,字段中有结构体级别验证仍需遍历
+		partial := validateTag == blank && !v.hasStructLevel(sf.Type, map[reflect.Type]bool{})
+		if partial && !hasNestedField(sf.Type) {
+			continue
+		}
+		cf := &cField{
+			idx:       i,
+			name:      sf.Name,
+			jsonName:  jsonName(&sf),
+			inline:    sf.Anonymous && sf.Tag.Get(jsonTag) == blank,
+			alias:     jsonName(&sf),
+			formName:  formName(&sf),
+			formAlias: formName(&sf),
+			sf:        sf,
+			partial:   partial,
+		}
+		//如果设置字段别名
+		if descTag := sf.Tag.Get(conf.FieldDescribeTag); descTag != blank {
+			cf.alias, cf.formAlias = descTag, descTag
+		}
+		cf.rules, cf.err = v.parseTags(tags, sf.Name, conf)
+		cs.fields = append(cs.fields, cf)
+	}
+	return cs
+}
+
+// parseTags 解析字段验证tag,遇到 dive 时递归解析元素的验证规则
+func (v *Validator) parseTags(tags []string, fieldName string, conf *Config) (*cRules, error) {
+	rules := &cRules{tags: make([]*cTag, 0, len(tags))}
+	for i := 0; i < len(tags); i++ {
+		validaTag := tags[i]
+		switch validaTag {
+		case diveTag:
+			var err error
+			elemTags := tags[i+1:]
+			// dive,keys,...,endkeys 解析 map key 的验证规则
+			if len(elemTags) > 0 && elemTags[0] == keysTag {
+				end := -1
+				for j := 1; j < len(elemTags); j++ {
+					if elemTags[j] == endKeysTag {
+						end = j
+						break
+					}
+				}
+				if end == -1 {
+					return nil, errors.New(strings.TrimSpace(fmt.Sprintf(missingEndKeysTag, fieldName)))
+				}
+				if rules.keys, err = v.parseTags(elemTags[1:end], fieldName, conf); err != nil {
+					return nil, err
+				}
+				elemTags = elemTags[end+1:]
+			}
+			if rules.dive, err = v.parseTags(elemTags, fieldName, conf); err != nil {
+				return nil, err
+			}
+			return rules, nil
+		case keysTag, endKeysTag:
+			return nil, errors.New(strings.TrimSpace(fmt.Sprintf(invalidKeysTag, fieldName)))
+		}
+		if conf.OmitemptyTag != blank && validaTag == conf.OmitemptyTag {
+			rules.tags = append(rules.tags, &cTag{isOmitempty: true})
+			continue
+		}
+		ct := &cTag{raw: validaTag}
+		orVials := strings.Split(validaTag, orSeparator)
+		for j := 0; j < len(orVials); j++ {
+			// 获取验证值
+			vals := strings.SplitN(orVials[j], tagKeySeparator, 2)
+			rule := &cRule{tag: vals[0]}
+			if len(rule.tag) == 0 {
+				return nil, errors.New(strings.TrimSpace(fmt.Sprintf(invalidValidation, fieldName)))
+			}
+			if len(vals) > 1 {
+				rule.param = strings.NewReplacer(utf8HexComma, tagSeparator, utf8Pipe, orSeparator, utf8At, sceneSeparator).Replace(vals[1])
+			}
+			validationFunc, ok := v.getValidationFunc(rule.tag)
+			if !ok {
+				return nil, errors.New(strings.TrimSpace(fmt.Sprintf(undefinedValidation, fieldName)))
+			}
+			rule.fn = validationFunc
+			rule.p = newTagParam(rule.param)
+			ct.ors = append(ct.ors, rule)
+		}
+		if len(ct.ors) == 1 {
+			ct.skipWhenEmpty, ct.isConditional = conditionalFuncS[ct.ors[0].tag]
+		}
+		rules.tags = append(rules.tags, ct)
+	}
+	return rules, nil
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStructCacheReuse(t *testing.T) {
+	v := New()
+	typ := reflect.TypeOf(collectForm{})
+	cs := v.extractStructCache(typ, blank)
+	if v.extractStructCache(typ, blank) != cs {
+		t.Fatal("struct rules parsed again")
+	}
+	if n := len(cs.fields); n != 4 {
+		t.Fatalf("cached fields = %d, want 4", n)
+	}
+	// 配置的 tag 名称不同时分别缓存
+	v.GetConfig().FieldDescribeTag = "label"
+	if other := v.extractStructCache(typ, blank); other == cs || other.fields[0].alias != "name" {
+		t.Fatalf("cache not keyed by config, alias = %s", other.fields[0].alias)
+	}
+	v.GetConfig().FieldDescribeTag = defaultFieldDescribeTag
+	if v.extractStructCache(typ, blank) != cs {
+		t.Fatal("cache for default config lost")
+	}
+	if New().extractStructCache(typ, blank) == cs {
+		t.Fatal("cache shared between instances")
+	}
+}
+
+func TestStructCacheInvalidation(t *testing.T) {
+	v := New()
+	form := &skuForm{Sku: "SKU1", Email: "a@b.cn"}
+	assertMessage(t, v.Binding(form), "Undefined validation function on field Sku")
+
+	// 注册验证方法后重新解析已缓存的规则
+	if err := v.RegisterValidation("sku", func(tag *Tag) bool { return tag.GetValue().String() == "SKU1" }); err != nil {
+		t.Fatal(err)
+	}
+	assertMessage(t, v.Binding(form), "")
+	form.Email = "x"
+	assertMessage(t, v.Binding(form), "邮箱必须是一个有效的邮箱")
+	if err := v.RegisterValidation("email", func(tag *Tag) bool { return true }, true); err != nil {
+		t.Fatal(err)
+	}
+	assertMessage(t, v.Binding(form), "")
+}
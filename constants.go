@@ -5,17 +5,48 @@ const (
 	defaultValidationTag    = "validate"
 	defaultFieldDescribeTag = "desc"
 	defaultOmitemptyTag     = "omitempty"
+	jsonTag                 = "json"
+	formTag                 = "form"
+	headerTag               = "header"
 )
 
 const (
 	blank               = ""
 	utf8HexComma        = "0x2C"
 	utf8Pipe            = "0x7C"
+	utf8At              = "0x40"
 	tagSeparator        = ","
+	pathSeparator       = "."
 	orSeparator         = "|"
+	sceneSeparator      = "@"
 	tagKeySeparator     = "="
 	skipValidationTag   = "-"
+	requiredTag         = "required"
+	diveTag             = "dive"
+	keysTag             = "keys"
+	endKeysTag          = "endkeys"
+	unknownFieldTag     = "unknown_field"
+	typeTagPrefix       = "type_"
+	jsonSyntaxTag       = "json_syntax"
+	structLevelTag      = "struct"
 	invalidValidation   = "Invalid validation tag on field %s"
 	undefinedValidation = "Undefined validation function on field %s"
+	invalidKeysTag      = "'keys' must be immediately preceded by 'dive' on field %s"
+	missingEndKeysTag   = "'endkeys' not found after 'keys' on field %s"
+	invalidDive         = "Dive on non collection field %s"
+	invalidMapRule      = "Invalid rule type on key %s, must be string or map[string]interface{}"
 	mustStruct          = "Object Must Struct"
+	emptyFuncName       = "Validation function name cannot be empty"
+	invalidFuncName     = "Validation function name %s contains reserved characters"
+	nilValidationFunc   = "Validation function %s cannot be nil"
+	builtinValidation   = "Validation function %s is built-in, set override to replace it"
+	errorsSeparator     = "; "
+	unsupportedMedia    = "Unsupported Content-Type %s"
+)
+
+// 请求参数解析
+const (
+	maxRuleCacheSize       = 1024     //map/Var 验证规则缓存上限,规则动态拼接时避免缓存无限增长
+	maxFormSliceIndex      = 10000    //form 参数切片下标上限,避免 eg: tags[100000000] 分配过大的切片
+	defaultMultipartMemory = 32 << 20 //multipart 参数保存在内存中的大小上限
 )
@@ -0,0 +1,110 @@
+package validator
+
+var (
+	enTranslateWords = &TranslateWords{
+		Or:        " or ",
+		Undefined: "Invalid parameter",
+		Invalid:   "Invalid translation",
+		Subject:   "Value",
+	}
+
+	// {0} == field.AliasName
+	// {1} == field.Tags.param
+	defaultEnTranslateMap = map[string]string{
+		"required": "{0} is a required field",
+		"eq":       "{0} is not equal to {1}",
+		"ne":       "{0} should not be equal to {1}",
+		"email":    "{0} must be a valid email address",
+		"oneof":    "{0} must be one of [{1}]",
+		//len相关
+		"len-string":  "{0} must be {1} characters in length",
+		"len-int":     "{0} must be equal to {1}",
+		"len-int64":   "{0} must be equal to {1}",
+		"len-float64": "{0} must be equal to {1}",
+		"len-slice":   "{0} must contain {1} items",
+		"len-map":     "{0} must contain {1} items",
+		//min相关
+		"min-string":  "{0} must be at least {1} characters in length",
+		"min-int":     "{0} must be {1} or greater",
+		"min-int64":   "{0} must be {1} or greater",
+		"min-float64": "{0} must be {1} or greater",
+		"min-slice":   "{0} must contain at least {1} items",
+		"min-map":     "{0} must contain at least {1} items",
+		//max相关
+		"max-string":  "{0} must be a maximum of {1} characters in length",
+		"max-int":     "{0} must be {1} or less",
+		"max-int64":   "{0} must be {1} or less",
+		"max-float64": "{0} must be {1} or less",
+		"max-slice":   "{0} must contain at maximum {1} items",
+		"max-map":     "{0} must contain at maximum {1} items",
+		//lt相关
+		"lt-string":  "{0} must be less than {1} characters in length",
+		"lt-int":     "{0} must be less than {1}",
+		"lt-int64":   "{0} must be less than {1}",
+		"lt-float64": "{0} must be less than {1}",
+		"lt-slice":   "{0} must contain less than {1} items",
+		"lt-map":     "{0} must contain less than {1} items",
+		"lt-struct":  "{0} must be before the current time",
+		//lte相关
+		"lte-string":  "{0} must be at maximum {1} characters in length",
+		"lte-int":     "{0} must be {1} or less",
+		"lte-int64":   "{0} must be {1} or less",
+		"lte-float64": "{0} must be {1} or less",
+		"lte-slice":   "{0} must contain at maximum {1} items",
+		"lte-map":     "{0} must contain at maximum {1} items",
+		"lte-struct":  "{0} must be before or equal to the current time",
+		//gt相关
+		"gt-string":  "{0} must be greater than {1} characters in length",
+		"gt-int":     "{0} must be greater than {1}",
+		"gt-int64":   "{0} must be greater than {1}",
+		"gt-float64": "{0} must be greater than {1}",
+		"gt-slice":   "{0} must contain more than {1} items",
+		"gt-map":     "{0} must contain more than {1} items",
+		"gt-struct":  "{0} must be after the current time",
+		//gte相关
+		"gte-string":  "{0} must be at least {1} characters in length",
+		"gte-int":     "{0} must be {1} or greater",
+		"gte-int64":   "{0} must be {1} or greater",
+		"gte-float64": "{0} must be {1} or greater",
+		"gte-slice":   "{0} must contain at least {1} items",
+		"gte-map":     "{0} must contain at least {1} items",
+		"gte-struct":  "{0} must be after or equal to the current time",
+		//跨字段比较相关
+		"eqfield":  "{0} must be equal to {1}",
+		"nefield":  "{0} cannot be equal to {1}",
+		"gtfield":  "{0} must be greater than {1}",
+		"gtefield": "{0} must be greater than or equal to {1}",
+		"ltfield":  "{0} must be less than {1}",
+		"ltefield": "{0} must be less than or equal to {1}",
+		//条件必填相关
+		"required_if":          "{0} is a required field",
+		"required_unless":      "{0} is a required field",
+		"required_with":        "{0} is a required field",
+		"required_with_all":    "{0} is a required field",
+		"required_without":     "{0} is a required field",
+		"required_without_all": "{0} is a required field",
+		//条件禁止填写相关
+		"excluded_if":          "{0} must not be provided",
+		"excluded_unless":      "{0} must not be provided",
+		"excluded_with":        "{0} must not be provided",
+		"excluded_with_all":    "{0} must not be provided",
+		"excluded_without":     "{0} must not be provided",
+		"excluded_without_all": "{0} must not be provided",
+		//json 解析相关
+		"unknown_field": "{0} is an unknown field",
+		"type_number":   "{0} must be a number",
+		"type_string":   "{0} must be a string",
+		"type_bool":     "{0} must be a boolean",
+		"type_array":    "{0} must be an array",
+		"type_object":   "{0} must be an object",
+		"type_invalid":  "{0} has an invalid type",
+		"json_syntax":   "Invalid JSON at offset {1}",
+		//结构体级别验证,{1} 为 Validate 方法返回的错误信息
+		"struct": "{1}",
+	}
+)
+
+// NewEnTranslate 英文翻译器
+func NewEnTranslate() *MapTranslator {
+	return NewMapTranslator(LocaleEn, defaultEnTranslateMap, enTranslateWords)
+}
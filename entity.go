@@ -2,26 +2,155 @@ package validator
 
 import (
 	"reflect"
+	"strings"
 )
 
 type Config struct {
 	FieldDescribeTag string
 	ValidationTag    string
 	OmitemptyTag     string
+	CollectAll       bool //是否收集全部错误,默认遇到第一个错误立即返回
+	// BindingJSON 时是否将结构体中不存在的 json 字段作为验证错误返回
+	DisallowUnknownFields bool
+	// BindRequest 解析 json 请求体时是否与 BindingJSON 一致按字段是否存在判断 required、omitempty
+	// 默认按零值判断,与 Binding 一致
+	JSONPresence bool
 }
 
 // Field 字段信息
 type Field struct {
-	Idx       int                  //字段下标
-	AliasName string               //字段别名
-	Sf        *reflect.StructField //字段类型
-	Tags      *Tag                 //字段tag信息
+	Idx        int                  //字段下标
+	AliasName  string               //字段别名
+	JSONName   string               //json 标签名
+	Path       string               //json 完整路径 eg: addresses[1].street
+	StructPath string               //结构体完整路径 eg: Addresses[1].Street
+	Sf         *reflect.StructField //字段类型
+	Tags       *Tag                 //字段tag信息
 }
 
 //Tag 解析信息
 type Tag struct {
-	tag       string         //tag 名称
-	param     string         //验证tag标签值 eg: max=100 ; param=100
-	isHaveErr bool           //是否有验证错误
-	rv        *reflect.Value //验证struct对应的字段信息
+	tag        string               //tag 名称
+	param      string               //验证tag标签值 eg: max=100 ; param=100
+	isHaveErr  bool                 //是否有验证错误
+	rv         *reflect.Value       //验证struct对应的字段信息
+	p          *tagParam            //预解析的参数,为空时实时解析
+	parent     reflect.Value        //字段所在的结构体
+	top        reflect.Value        //最外层结构体
+	ors        []*cRule             //多个验证方法全部失败时的验证方法列表 eg: email|len=11
+	present    *bool                //BindingJSON 时 json 中是否存在该字段,为空时按零值判断
+	presence   presenceSet          //BindingJSON 时 json 中存在的字段路径,跨字段判断是否有值时使用
+	paths      []string             //ancestors 对应的 json 路径
+	ancestors  []reflect.Value      //字段所在结构体的各级上级结构体,由外到内
+	paramSf    *reflect.StructField //跨字段验证参数对应的字段,翻译时使用其别名
+	paramAlias string               //跨字段验证参数对应字段的别名 eg: eqfield=Password ; 密码
+	message    string               //结构体级别验证指定的错误信息,不为空时不再翻译
+}
+
+// GetOrs 多个验证方法全部失败时的每个验证方法 eg: email|len=11,自定义翻译器逐个翻译时使用
+func (t *Tag) GetOrs() []*Tag {
+	ors := make([]*Tag, 0, len(t.ors))
+	for _, rule := range t.ors {
+		or := *t
+		or.tag, or.param, or.p, or.ors = rule.tag, rule.param, rule.p, nil
+		ors = append(ors, &or)
+	}
+	return ors
+}
+
+// GetTag 验证tag名称 eg: max
+func (t *Tag) GetTag() string {
+	return t.tag
+}
+
+// GetParam 验证tag参数 eg: max=100 ; param=100
+func (t *Tag) GetParam() string {
+	return t.param
+}
+
+// GetValue 待验证的字段值(已解引用指针及接口)
+func (t *Tag) GetValue() reflect.Value {
+	return *t.rv
+}
+
+// GetParent 字段所在的结构体
+func (t *Tag) GetParent() reflect.Value {
+	return t.parent
+}
+
+// GetTop 最外层结构体
+func (t *Tag) GetTop() reflect.Value {
+	return t.top
+}
+
+// LookupField 按字段路径获取字段值(已解引用指针及接口) eg: Password, Job.Id
+// 从字段所在的结构体开始,逐级向外层结构体查找,最后从最外层结构体查找
+// path 为空时返回 VarWithValue 传入的比较值
+func (t *Tag) LookupField(path string) (reflect.Value, bool) {
+	current, _, _, ok := t.lookup(path)
+	return current, ok
+}
+
+// hasField 指定字段是否有值,BindingJSON 时按 json 中是否存在该字段判断 eg: "count":0 有值
+func (t *Tag) hasField(path string) bool {
+	current, _, jsonPath, ok := t.lookup(path)
+	if ok && t.presence != nil {
+		return t.presence.has(jsonPath)
+	}
+	return ok && !isZeroValue(current)
+}
+
+// lookup 按字段路径获取字段值、字段信息及 json 路径,map 中的值没有字段信息
+func (t *Tag) lookup(path string) (reflect.Value, *reflect.StructField, string, bool) {
+	// 字段所在结构体即最内层的上级结构体
+	parentPath := blank
+	if len(t.paths) > 0 {
+		parentPath = t.paths[len(t.paths)-1]
+	}
+	if path == blank {
+		current, _ := extractTypeInternal(t.parent)
+		return current, nil, parentPath, current.IsValid()
+	}
+	if current, sf, jsonPath, ok := lookupField(t.parent, path); ok {
+		return current, sf, joinPath(parentPath, jsonPath), true
+	}
+	for i := len(t.ancestors) - 1; i >= 0 && i < len(t.paths); i-- {
+		if current, sf, jsonPath, ok := lookupField(t.ancestors[i], path); ok {
+			return current, sf, joinPath(t.paths[i], jsonPath), true
+		}
+	}
+	return lookupField(t.top, path)
+}
+
+// lookupField 从 current 开始按字段路径逐级查找字段值,同时返回对应的 json 路径
+func lookupField(current reflect.Value, path string) (reflect.Value, *reflect.StructField, string, bool) {
+	if !current.IsValid() || path == blank {
+		return reflect.Value{}, nil, blank, false
+	}
+	var sf *reflect.StructField
+	jsonPath := blank
+	for _, name := range strings.Split(path, pathSeparator) {
+		current, _ = extractTypeInternal(current)
+		switch current.Kind() {
+		case reflect.Struct:
+			f, ok := current.Type().FieldByName(name)
+			if !ok {
+				return reflect.Value{}, nil, blank, false
+			}
+			current, sf, jsonPath = current.FieldByIndex(f.Index), &f, joinPath(jsonPath, jsonName(&f))
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, nil, blank, false
+			}
+			key := reflect.ValueOf(name).Convert(current.Type().Key())
+			current, sf, jsonPath = current.MapIndex(key), nil, keyPath(jsonPath, key)
+		default:
+			return reflect.Value{}, nil, blank, false
+		}
+		if !current.IsValid() {
+			return reflect.Value{}, nil, blank, false
+		}
+	}
+	current, _ = extractTypeInternal(current)
+	return current, sf, jsonPath, current.IsValid()
 }
@@ -0,0 +1,99 @@
+package validator
+
+// defaultErrorCodes 内置验证方法的错误码,新增验证方法时在末尾追加,已有错误码不再修改
+var defaultErrorCodes = map[string]int{
+	"required": 10001,
+	"len":      10002,
+	"eq":       10003,
+	"ne":       10004,
+	"email":    10005,
+	"oneof":    10006,
+	"min":      10007,
+	"max":      10008,
+	"lt":       10009,
+	"lte":      10010,
+	"gt":       10011,
+	"gte":      10012,
+	//跨字段比较
+	"eqfield":  10013,
+	"nefield":  10014,
+	"gtfield":  10015,
+	"gtefield": 10016,
+	"ltfield":  10017,
+	"ltefield": 10018,
+	//条件必填
+	"required_if":          10019,
+	"required_unless":      10020,
+	"required_with":        10021,
+	"required_with_all":    10022,
+	"required_without":     10023,
+	"required_without_all": 10024,
+	//条件禁止填写
+	"excluded_if":          10025,
+	"excluded_unless":      10026,
+	"excluded_with":        10027,
+	"excluded_with_all":    10028,
+	"excluded_without":     10029,
+	"excluded_without_all": 10030,
+	//请求参数解析
+	"unknown_field": 10031,
+	"type_number":   10032,
+	"type_string":   10033,
+	"type_bool":     10034,
+	"type_array":    10035,
+	"type_object":   10036,
+	"type_invalid":  10037,
+	"json_syntax":   10038,
+	//结构体级别验证
+	"struct": 10039,
+	//多个验证方法全部失败 eg: email|len=11
+	orSeparator: 10040,
+}
+
+// RegisterErrorCode 设置验证方法的错误码,可为自定义验证方法设置错误码或覆盖内置错误码
+// eg: v.RegisterErrorCode("sku", 20001)
+func (v *Validator) RegisterErrorCode(name string, code int) *Validator {
+	v.codesLock.Lock()
+	if v.codes == nil {
+		v.codes = map[string]int{}
+	}
+	v.codes[name] = code
+	v.codesLock.Unlock()
+	return v
+}
+
+// GetErrorCode 获取验证方法的错误码,先查找当前实例设置的错误码,再查找内置错误码
+func (v *Validator) GetErrorCode(name string) (int, bool) {
+	v.codesLock.RLock()
+	code, ok := v.codes[name]
+	v.codesLock.RUnlock()
+	if ok {
+		return code, true
+	}
+	code, ok = defaultErrorCodes[name]
+	return code, ok
+}
+
+// errorCode 验证失败字段的错误码,多个验证方法全部失败时使用 orSeparator 的错误码 eg: email|len=11
+// 未设置错误码时为 0
+func (v *Validator) errorCode(tag *Tag) int {
+	name := tag.tag
+	if len(tag.ors) > 0 {
+		name = orSeparator
+	}
+	code, _ := v.GetErrorCode(name)
+	return code
+}
+
+// orErrorCodes 多个验证方法全部失败时每个验证方法的错误码,按 tag 中的顺序
+func (v *Validator) orErrorCodes(tag *Tag) []int {
+	if len(tag.ors) == 0 {
+		return nil
+	}
+	codes := make([]int, 0, len(tag.ors))
+	for _, rule := range tag.ors {
+		code, _ := v.GetErrorCode(rule.tag)
+		codes = append(codes, code)
+	}
+	return codes
+}
@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorCodesCoverBuiltins(t *testing.T) {
+	seen := map[int]string{}
+	for name, code := range defaultErrorCodes {
+		if other, ok := seen[code]; ok {
+			t.Fatalf("%s and %s share code %d", name, other, code)
+		}
+		seen[code] = name
+	}
+	for name := range validationFuncS {
+		if _, ok := defaultErrorCodes[name]; !ok {
+			t.Fatalf("%s has no error code", name)
+		}
+	}
+	// 按类型区分的模板 eg: max-string 使用验证方法的错误码
+	for name := range defaultTranslateMap {
+		if _, ok := defaultErrorCodes[name]; !ok && !strings.Contains(name, "-") {
+			t.Fatalf("%s has no error code", name)
+		}
+	}
+}
+
+func TestFieldErrorCode(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	res := v.Binding(&orForm{Contact: "abc", Ids: []string{"1"}})
+	// 全部失败时错误码为 10040,OrCodes 按顺序返回每个验证方法的错误码
+	want := [][]int{{10040, 10005, 10002}, {10040, 10002, 10002}}
+	for i, fe := range res.Errors() {
+		if got := append([]int{fe.Code}, fe.OrCodes...); !reflect.DeepEqual(got, want[i]) {
+			t.Fatalf("errors[%d] codes = %v, want %v", i, got, want[i])
+		}
+	}
+	if fe := v.Var("", "required").Errors()[0]; fe.OrCodes != nil {
+		t.Fatalf("OrCodes = %v", fe.OrCodes)
+	}
+
+	if code, ok := v.GetErrorCode("email"); !ok || code != 10005 {
+		t.Fatalf("GetErrorCode(email) = %d, %v", code, ok)
+	}
+	if _, ok := v.GetErrorCode("sku"); ok {
+		t.Fatal("sku should have no error code")
+	}
+	if err := v.RegisterValidation("sku", func(*Tag) bool { return false }); err != nil {
+		t.Fatal(err)
+	}
+	if fe := v.Var("a", "sku").Errors()[0]; fe.Code != 0 {
+		t.Fatalf("code = %d, want 0", fe.Code)
+	}
+	v.RegisterErrorCode("sku", 20001).RegisterErrorCode("required", 40001)
+	if fe := v.Var("a", "sku").Errors()[0]; fe.Code != 20001 {
+		t.Fatalf("code = %d, want 20001", fe.Code)
+	}
+	if fe := v.Var("", "required").Errors()[0]; fe.Code != 40001 {
+		t.Fatalf("code = %d, want 40001", fe.Code)
+	}
+	// 其他实例不受影响
+	if fe := New().Var("", "required").Errors()[0]; fe.Code != 10001 {
+		t.Fatalf("code = %d, want 10001", fe.Code)
+	}
+	v.RegisterErrorCode("|", 40040)
+	if fe := v.Var("abc", "email|len=2").Errors()[0]; fe.Code != 40040 || !reflect.DeepEqual(fe.OrCodes, []int{10005, 10002}) {
+		t.Fatalf("codes = %d %v", fe.Code, fe.OrCodes)
+	}
+	if fe := v.BindingJSON([]byte(`{"age":`), &jsonForm{}).Errors()[0]; fe.Code != 10038 {
+		t.Fatalf("json_syntax code = %d", fe.Code)
+	}
+}
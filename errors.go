@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldError 单个字段的验证错误
+type FieldError struct {
+	Field      string      //结构体字段名 eg: Street
+	JSONName   string      //json 标签名 eg: street
+	Alias      string      //desc 别名 eg: 街道
+	Path       string      //json 完整路径 eg: addresses[1].street
+	StructPath string      //结构体完整路径 eg: Addresses[1].Street
+	Tag        string      //验证失败的tag eg: max
+	Code       int         //验证方法的错误码 eg: required=10001,未设置时为 0
+	OrCodes    []int       //多个验证方法全部失败时每个验证方法的错误码 eg: email|len=11 ; [10005, 10002]
+	Param      string      //tag 参数 eg: max=10 ; Param=10
+	Value      interface{} //字段值
+	Message    string      //翻译后的错误信息
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors 验证错误列表,可通过 errors.As 获取
+type ValidationErrors []*FieldError
+
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(ve))
+	for _, fe := range ve {
+		msgs = append(msgs, fe.Error())
+	}
+	return strings.Join(msgs, errorsSeparator)
+}
+
+// newFieldError 由验证失败字段及翻译信息生成 FieldError
+func newFieldError(field *Field, err error) *FieldError {
+	fe := &FieldError{
+		Field:      field.Sf.Name,
+		JSONName:   field.JSONName,
+		Alias:      field.AliasName,
+		Path:       field.Path,
+		StructPath: field.StructPath,
+		Tag:        field.Tags.tag,
+		Param:      field.Tags.param,
+		Message:    err.Error(),
+	}
+	if rv := field.Tags.rv; rv != nil && rv.IsValid() && rv.CanInterface() {
+		fe.Value = rv.Interface()
+	}
+	return fe
+}
+
+// jsonName 获取字段 json 标签名,未设置时使用字段名
+func jsonName(sf *reflect.StructField) string {
+	name := strings.SplitN(sf.Tag.Get(jsonTag), tagSeparator, 2)[0]
+	if name == blank || name == "-" {
+		return sf.Name
+	}
+	return name
+}
+
+// joinPath 拼接字段路径
+func joinPath(parent, name string) string {
+	if parent == blank {
+		return name
+	}
+	return parent + pathSeparator + name
+}
+
+// indexPath 拼接切片下标路径 eg: addresses[1]
+func indexPath(parent string, idx int) string {
+	return parent + "[" + strconv.Itoa(idx) + "]"
+}
+
+// keyPath 拼接 map key 路径 eg: scores[math]
+func keyPath(parent string, key reflect.Value) string {
+	return parent + "[" + fmt.Sprint(key.Interface()) + "]"
+}
+
+// sortedMapKeys 排序后的 map key,保证错误顺序稳定
+func sortedMapKeys(current reflect.Value) []reflect.Value {
+	keys := current.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
+
+// hasNestedField 元素类型是否可能包含需要验证的结构体
+func hasNestedField(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
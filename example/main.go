@@ -13,7 +13,7 @@ type User struct {
 	FirstName *string    `json:"fname" validate:"omitempty,required,min=1,max=5" desc:"姓氏"`
 	LastName  string     `json:"lname" validate:"required" desc:"名称"`
 	Age       int        `json:"age" validate:"omitempty,gte=0,lte=100" desc:"年龄"`
-	Credit    []*int     `json:"credit" validate:"required,min=1,max=100" desc:"学分"`
+	Credit    []*int     `json:"credit" validate:"required,min=1,max=100,dive,required,gte=0,lte=100" desc:"学分"`
 	Sex       *int       `json:"sex" validate:"required,oneof=1 2" desc:"性别"`
 	Email     string     `json:"email" validate:"required,email" desc:"邮件"`
 	Job       *Job       `json:"job" validate:"required" desc:"工作"`
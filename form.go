@@ -0,0 +1,298 @@
+package validator
+
+import (
+	"encoding"
+	"mime/multipart"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+)
+
+// formLeaf 设置 form 字段值,转换失败时返回验证失败信息
+type formLeaf func(current reflect.Value, sf *reflect.StructField, path, structPath string) *Field
+
+// bindForm 按 form 参数填充结构体,key 支持嵌套格式 eg: name, tags, tags[0], job[id], job.id, addrs[0][street]
+// 字段名依次使用 form、json 标签,返回是否有参数转换失败,未开启 CollectAll 时遇到第一个错误立即返回
+func (v *validate) bindForm(current reflect.Value, values map[string][]string) bool {
+	return v.bindFormKeys(current, sortedFormKeys(values), func(current reflect.Value, sf *reflect.StructField, path, structPath string, key string) *Field {
+		field := v.setFormValue(current, sf, path, structPath, values[key])
+		// json 请求中通过 query 填充的字段同样视为存在
+		if field == nil && v.presence != nil {
+			v.presence.add(path)
+		}
+		return field
+	})
+}
+
+// bindFormFiles 按上传文件填充 *multipart.FileHeader 及 []*multipart.FileHeader 字段
+func (v *validate) bindFormFiles(current reflect.Value, files map[string][]*multipart.FileHeader) {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	v.bindFormKeys(current, keys, func(current reflect.Value, _ *reflect.StructField, _, _ string, key string) *Field {
+		switch current.Type() {
+		case fileHeaderType:
+			current.Set(reflect.ValueOf(files[key][0]))
+		case reflect.SliceOf(fileHeaderType):
+			current.Set(reflect.ValueOf(files[key]))
+		}
+		return nil
+	})
+}
+
+// bindFormKeys 依次查找每个 key 对应的字段并设置字段值,不存在的字段忽略
+func (v *validate) bindFormKeys(current reflect.Value, keys []string, leaf func(reflect.Value, *reflect.StructField, string, string, string) *Field) bool {
+	isHaveErr := false
+	for _, key := range keys {
+		key := key
+		field := v.setFormField(current, splitFormKey(key), nil, blank, blank, func(current reflect.Value, sf *reflect.StructField, path, structPath string) *Field {
+			return leaf(current, sf, path, structPath, key)
+		})
+		if field == nil {
+			continue
+		}
+		v.res.fields = append(v.res.fields, field)
+		if !v.v.GetConfig().CollectAll {
+			return true
+		}
+		isHaveErr = true
+	}
+	return isHaveErr
+}
+
+// setFormField 按 key 的每一级查找字段,指针、切片、map 为空时自动创建
+func (v *validate) setFormField(current reflect.Value, segments []string, sf *reflect.StructField, path, structPath string, leaf formLeaf) *Field {
+	if len(segments) == 0 {
+		return leaf(current, sf, path, structPath)
+	}
+	for current.Kind() == reflect.Ptr {
+		if current.IsNil() {
+			current.Set(reflect.New(current.Type().Elem()))
+		}
+		current = current.Elem()
+	}
+	seg := segments[0]
+	switch current.Kind() {
+	case reflect.Struct:
+		if reflect.PtrTo(current.Type()).Implements(textUnmarshalerType) {
+			return nil
+		}
+		field, ok := lookupJSONField(tagFields(current.Type(), formTag, jsonTag), seg)
+		if !ok {
+			return nil
+		}
+		return v.setFormField(fieldByIndex(current, field.Index), segments[1:], &field,
+			joinPath(path, v.fieldName(&field)), joinPath(structPath, field.Name), leaf)
+	case reflect.Slice, reflect.Array:
+		// tags[]=a&tags[]=b
+		if seg == blank && len(segments) == 1 {
+			return leaf(current, sf, path, structPath)
+		}
+		idx, err := strconv.Atoi(seg)
+		if err != nil || idx < 0 || idx >= maxFormSliceIndex {
+			return nil
+		}
+		if idx >= current.Len() {
+			if current.Kind() == reflect.Array {
+				return nil
+			}
+			// 下标不连续时中间的元素为零值并照常验证
+			grown := reflect.MakeSlice(current.Type(), idx+1, idx+1)
+			reflect.Copy(grown, current)
+			current.Set(grown)
+		}
+		return v.setFormField(current.Index(idx), segments[1:], sf, indexPath(path, idx), indexPath(structPath, idx), leaf)
+	case reflect.Map:
+		if current.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		if current.IsNil() {
+			current.Set(reflect.MakeMap(current.Type()))
+		}
+		key := reflect.ValueOf(seg).Convert(current.Type().Key())
+		elem := reflect.New(current.Type().Elem()).Elem()
+		if exist := current.MapIndex(key); exist.IsValid() {
+			elem.Set(exist)
+		}
+		field := v.setFormField(elem, segments[1:], sf, keyPath(path, key), keyPath(structPath, key), leaf)
+		current.SetMapIndex(key, elem)
+		return field
+	}
+	return nil
+}
+
+// setFormValue 将 form 参数转换为字段类型,同一 key 有多个值时设置到切片
+func (v *validate) setFormValue(current reflect.Value, sf *reflect.StructField, path, structPath string, vals []string) *Field {
+	if len(vals) == 0 {
+		return nil
+	}
+	for current.Kind() == reflect.Ptr {
+		if current.IsNil() {
+			current.Set(reflect.New(current.Type().Elem()))
+		}
+		current = current.Elem()
+	}
+	typ := current.Type()
+	if reflect.PtrTo(typ).Implements(textUnmarshalerType) {
+		if err := current.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(vals[0])); err != nil {
+			return v.formTypeField(sf, path, structPath, typeTagPrefix+"invalid", vals[0])
+		}
+		return nil
+	}
+	var err error
+	switch typ.Kind() {
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			current.SetBytes([]byte(vals[0]))
+			return nil
+		}
+		slice := reflect.MakeSlice(typ, len(vals), len(vals))
+		for i, val := range vals {
+			if field := v.setFormValue(slice.Index(i), sf, indexPath(path, i), indexPath(structPath, i), []string{val}); field != nil {
+				return field
+			}
+		}
+		current.Set(slice)
+		return nil
+	case reflect.Array:
+		for i := 0; i < len(vals) && i < current.Len(); i++ {
+			if field := v.setFormValue(current.Index(i), sf, indexPath(path, i), indexPath(structPath, i), []string{vals[i]}); field != nil {
+				return field
+			}
+		}
+		return nil
+	case reflect.Interface:
+		if current.NumMethod() == 0 {
+			if len(vals) == 1 {
+				current.Set(reflect.ValueOf(vals[0]))
+			} else {
+				current.Set(reflect.ValueOf(vals))
+			}
+		}
+		return nil
+	case reflect.String:
+		current.SetString(vals[0])
+		return nil
+	}
+	val := strings.TrimSpace(vals[0])
+	if val == blank {
+		return nil
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(val, 10, typ.Bits()); err == nil {
+			current.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if n, err = strconv.ParseUint(val, 10, typ.Bits()); err == nil {
+			current.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		if n, err = strconv.ParseFloat(val, typ.Bits()); err == nil {
+			current.SetFloat(n)
+		}
+	case reflect.Bool:
+		// checkbox 选中时的值为 on
+		b := val == "on"
+		if !b {
+			b, err = strconv.ParseBool(val)
+		}
+		if err == nil {
+			current.SetBool(b)
+		}
+	}
+	if err != nil {
+		return v.formTypeField(sf, path, structPath, typeTagPrefix+jsonKind(typ), vals[0])
+	}
+	return nil
+}
+
+// formTypeField 创建参数类型转换失败的验证失败信息
+func (v *validate) formTypeField(sf *reflect.StructField, path, structPath, tag, val string) *Field {
+	rv := reflect.ValueOf(val)
+	field := &Field{
+		Path:       path,
+		StructPath: structPath,
+		Sf:         &reflect.StructField{Type: interfaceType},
+		Tags:       &Tag{tag: tag, param: "string", isHaveErr: true, rv: &rv},
+	}
+	if sf != nil {
+		field.Sf, field.JSONName, field.AliasName = sf, v.fieldName(sf), v.structFieldAlias(sf)
+	}
+	return field
+}
+
+// formName 获取字段 form 标签名,未设置时使用 json 标签名
+func formName(sf *reflect.StructField) string {
+	name := strings.SplitN(sf.Tag.Get(formTag), tagSeparator, 2)[0]
+	if name == blank || name == skipValidationTag {
+		return jsonName(sf)
+	}
+	return name
+}
+
+// fieldByIndex 按完整下标获取字段,匿名嵌入的指针为空时自动创建
+func fieldByIndex(current reflect.Value, index []int) reflect.Value {
+	for i, idx := range index {
+		if i > 0 {
+			for current.Kind() == reflect.Ptr {
+				if current.IsNil() {
+					current.Set(reflect.New(current.Type().Elem()))
+				}
+				current = current.Elem()
+			}
+		}
+		current = current.Field(idx)
+	}
+	return current
+}
+
+// splitFormKey 拆分 form key eg: addrs[0][street] → [addrs 0 street], job.id → [job id], tags[] → [tags ""]
+func splitFormKey(key string) []string {
+	segments := make([]string, 0, 4)
+	start := 0
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '.':
+			if i > start {
+				segments = append(segments, key[start:i])
+			}
+			start = i + 1
+		case '[':
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				continue
+			}
+			if i > start {
+				segments = append(segments, key[start:i])
+			}
+			segments = append(segments, key[i+1:i+end])
+			i += end
+			start = i + 1
+		}
+	}
+	if start < len(key) {
+		segments = append(segments, key[start:])
+	}
+	return segments
+}
+
+// sortedFormKeys 排序后的 form key,保证错误顺序稳定
+func sortedFormKeys(values map[string][]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,454 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// presenceSet json 中存在且不为 null 的字段路径,路径中的下标及 map key 统一为 pathSeparator 分隔
+// eg: addresses[1].street → addresses.1.street
+type presenceSet map[string]struct{}
+
+var presencePathReplacer = strings.NewReplacer("[", pathSeparator, "]", blank)
+
+// collect 递归记录对象及数组元素的路径
+// 结构体字段的 key 与 encoding/json 一致忽略大小写匹配,统一记录为 json 标签名 eg: AGE → age
+// typ 为 nil 时按 json 中的 key 记录 eg: 未知字段
+func (ps presenceSet) collect(raw interface{}, typ reflect.Type, path string) {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch val := raw.(type) {
+	case map[string]interface{}:
+		var fields map[string]reflect.StructField
+		if typ != nil && typ.Kind() == reflect.Struct {
+			fields = jsonFields(typ)
+		}
+		for key, elem := range val {
+			if elem == nil {
+				continue
+			}
+			var elemTyp reflect.Type
+			if fields != nil {
+				if sf, ok := lookupJSONField(fields, key); ok {
+					key, elemTyp = jsonName(&sf), sf.Type
+				}
+			} else if typ != nil && typ.Kind() == reflect.Map {
+				elemTyp = typ.Elem()
+			}
+			ps[joinPath(path, key)] = struct{}{}
+			ps.collect(elem, elemTyp, joinPath(path, key))
+		}
+	case []interface{}:
+		var elemTyp reflect.Type
+		if typ != nil && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+			elemTyp = typ.Elem()
+		}
+		for i, elem := range val {
+			if elem != nil {
+				ps[joinPath(path, strconv.Itoa(i))] = struct{}{}
+				ps.collect(elem, elemTyp, joinPath(path, strconv.Itoa(i)))
+			}
+		}
+	}
+}
+
+// has json 中是否存在该路径
+func (ps presenceSet) has(path string) bool {
+	_, ok := ps[presencePathReplacer.Replace(path)]
+	return ok
+}
+
+// add 记录字段路径及其全部上级路径 eg: job[id] → job, job.id
+func (ps presenceSet) add(path string) {
+	path = presencePathReplacer.Replace(path)
+	for path != blank {
+		ps[path] = struct{}{}
+		idx := strings.LastIndex(path, pathSeparator)
+		if idx < 0 {
+			break
+		}
+		path = path[:idx]
+	}
+}
+
+// skipped 字段是否为 json 类型错误的字段或其下级字段
+func (v *validate) skipped(path string) bool {
+	for _, skipPath := range v.skipPaths {
+		if !strings.HasPrefix(path, skipPath) {
+			continue
+		}
+		rest := path[len(skipPath):]
+		if rest == blank || strings.HasPrefix(rest, pathSeparator) || strings.HasPrefix(rest, "[") {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmpty 字段是否为空,BindingJSON 时按 json 中是否存在该字段判断,否则按零值判断
+func (v *validate) isEmpty(current reflect.Value, path string) bool {
+	if v.presence != nil && path != blank {
+		return !v.presence.has(path)
+	}
+	return isZeroValue(current)
+}
+
+// BindingJSON 解析 json 到 obj 后验证,required、omitempty 按 json 中是否存在该字段判断
+// eg: "age":0 满足 required, 缺少 age 或 "age":null 不满足
+func (v *Validator) BindingJSON(data []byte, obj interface{}) *Result {
+	return v.BindingJSONContext(context.Background(), data, obj)
+}
+
+// BindingJSONContext 同 BindingJSON,使用 ctx 中设置的语言及场景
+func (v *Validator) BindingJSONContext(ctx context.Context, data []byte, obj interface{}) *Result {
+	return v.newValidate(ctx).bindingJSON(data, obj, true, nil)
+}
+
+// bindingJSON 解析 json 并验证,presence 为 true 时按 json 中是否存在该字段判断 required、omitempty
+// before 在 json 解析后、验证前填充其他参数 eg: query, header
+func (v *validate) bindingJSON(data []byte, obj interface{}, presence bool, before func() bool) *Result {
+	if presence {
+		v.presence = presenceSet{}
+	}
+	// 先填充 query、header 参数,请求体中的同名字段覆盖
+	if before != nil && before() {
+		return v.translateFields()
+	}
+	if err := json.Unmarshal(data, obj); err != nil && v.decodeError(data, err, reflect.TypeOf(obj)) {
+		return v.translateFields()
+	}
+	var raw interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return v.res.SetError(err)
+	}
+	if presence {
+		v.presence.collect(raw, reflect.TypeOf(obj), blank)
+	}
+	// 存在未知字段且未开启 CollectAll 时不再验证字段
+	conf := v.v.GetConfig()
+	if conf.DisallowUnknownFields && v.extractUnknownFields(raw, reflect.TypeOf(obj), blank, blank) && !conf.CollectAll {
+		return v.translateFields()
+	}
+	return v.binding(obj)
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// extractUnknownFields 按结构体类型查找 json 中不存在对应字段的 key
+// 返回是否有未知字段,未开启 CollectAll 时遇到第一个未知字段立即返回
+func (v *validate) extractUnknownFields(raw interface{}, typ reflect.Type, path, structPath string) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	// 自定义解析的类型不检查 eg: time.Time
+	if reflect.PtrTo(typ).Implements(jsonUnmarshalerType) {
+		return false
+	}
+	isHaveErr := false
+	switch typ.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		fields := jsonFields(typ)
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			sf, ok := lookupJSONField(fields, key)
+			if !ok {
+				v.res.fields = append(v.res.fields, newUnknownField(key, obj[key], joinPath(path, key), joinPath(structPath, key)))
+			} else if !v.extractUnknownFields(obj[key], sf.Type, joinPath(path, key), joinPath(structPath, sf.Name)) {
+				continue
+			}
+			if !v.v.GetConfig().CollectAll {
+				return true
+			}
+			isHaveErr = true
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := raw.([]interface{})
+		if !ok {
+			return false
+		}
+		for i, elem := range arr {
+			if v.extractUnknownFields(elem, typ.Elem(), indexPath(path, i), indexPath(structPath, i)) {
+				if !v.v.GetConfig().CollectAll {
+					return true
+				}
+				isHaveErr = true
+			}
+		}
+	case reflect.Map:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for _, key := range sortedMapKeys(reflect.ValueOf(obj)) {
+			if v.extractUnknownFields(obj[key.String()], typ.Elem(), keyPath(path, key), keyPath(structPath, key)) {
+				if !v.v.GetConfig().CollectAll {
+					return true
+				}
+				isHaveErr = true
+			}
+		}
+	}
+	return isHaveErr
+}
+
+// jsonFields 结构体可解析的 json 字段,匿名嵌入且未设置json标签的结构体字段提升到上级
+func jsonFields(typ reflect.Type) map[string]reflect.StructField {
+	return tagFields(typ, jsonTag)
+}
+
+// tagFields 按标签名获取结构体字段,依次使用第一个已设置的标签,都未设置时使用字段名
+// 匿名嵌入且未设置标签的结构体字段提升到上级,Index 为相对 typ 的完整下标
+func tagFields(typ reflect.Type, tagNames ...string) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		name := blank
+		for _, tagName := range tagNames {
+			if name = strings.Split(sf.Tag.Get(tagName), tagSeparator)[0]; name != blank {
+				break
+			}
+		}
+		if name == skipValidationTag {
+			continue
+		}
+		if sf.Anonymous && name == blank {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, f := range tagFields(embedded, tagNames...) {
+					if _, ok := fields[key]; !ok {
+						f.Index = append([]int{i}, f.Index...)
+						fields[key] = f
+					}
+				}
+				continue
+			}
+		}
+		if sf.PkgPath != blank {
+			continue
+		}
+		if name == blank {
+			name = sf.Name
+		}
+		fields[name] = sf
+	}
+	return fields
+}
+
+// lookupJSONField 按 key 查找字段,与 encoding/json 一致优先完全匹配,其次忽略大小写匹配
+func lookupJSONField(fields map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if sf, ok := fields[key]; ok {
+		return sf, true
+	}
+	for name, sf := range fields {
+		if strings.EqualFold(name, key) {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// newUnknownField 创建未知字段的验证失败信息
+func newUnknownField(key string, raw interface{}, path, structPath string) *Field {
+	rv := reflect.ValueOf(raw)
+	return &Field{
+		AliasName:  key,
+		JSONName:   key,
+		Path:       path,
+		StructPath: structPath,
+		Sf:         &reflect.StructField{Name: key, Type: interfaceType},
+		Tags:       &Tag{tag: unknownFieldTag, isHaveErr: true, rv: &rv},
+	}
+}
+
+// decodeError 将 json 解析错误转换为验证失败信息,字段别名与结构体验证一致
+// 返回是否不再验证字段:json 格式错误、其他错误(eg: 自定义 UnmarshalJSON 返回的错误)及未开启 CollectAll 时的类型错误
+func (v *validate) decodeError(data []byte, err error, typ reflect.Type) bool {
+	switch e := err.(type) {
+	case *json.UnmarshalTypeError:
+		// encoding/json 只返回第一个类型错误,逐个字段解析获取全部类型错误
+		errs := typeErrors(data, typ, blank)
+		if len(errs) == 0 {
+			errs = append(errs, e)
+		}
+		for _, te := range errs {
+			field := v.v.typeErrorField(te, typ)
+			v.res.fields = append(v.res.fields, field)
+			if !v.v.GetConfig().CollectAll || field.Path == blank {
+				return true
+			}
+			// 开启 CollectAll 时跳过类型错误的字段及其下级字段,继续验证其他字段
+			v.skipPaths = append(v.skipPaths, field.Path)
+		}
+		return false
+	case *json.SyntaxError:
+		rv := reflect.ValueOf(e.Offset)
+		v.res.fields = append(v.res.fields, &Field{
+			Sf:   &reflect.StructField{Type: interfaceType},
+			Tags: &Tag{tag: jsonSyntaxTag, param: strconv.FormatInt(e.Offset, 10), isHaveErr: true, rv: &rv},
+		})
+	default:
+		v.res.SetError(err)
+	}
+	return true
+}
+
+// typeErrors 按结构体类型逐个字段解析 json,返回全部类型错误,按结构体字段顺序排列
+// Field 为包含下标及 map key 的完整路径 eg: addrs.1.street, jobs.k.id
+func typeErrors(data []byte, typ reflect.Type, field string) []*json.UnmarshalTypeError {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	var errs []*json.UnmarshalTypeError
+	switch {
+	// 自定义解析的类型整体解析 eg: time.Time
+	case reflect.PtrTo(typ).Implements(jsonUnmarshalerType):
+	case typ.Kind() == reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			break
+		}
+		fields := jsonFields(typ)
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			if _, ok := lookupJSONField(fields, key); ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			a, _ := lookupJSONField(fields, keys[i])
+			b, _ := lookupJSONField(fields, keys[j])
+			return lessIndex(a.Index, b.Index)
+		})
+		for _, key := range keys {
+			sf, _ := lookupJSONField(fields, key)
+			errs = append(errs, typeErrors(obj[key], sf.Type, joinPath(field, key))...)
+		}
+		return errs
+	// []byte 按 base64 字符串解析
+	case typ.Kind() == reflect.Array, typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8:
+		var arr []json.RawMessage
+		if json.Unmarshal(data, &arr) != nil {
+			break
+		}
+		for i, elem := range arr {
+			if typ.Kind() == reflect.Array && i >= typ.Len() {
+				break
+			}
+			errs = append(errs, typeErrors(elem, typ.Elem(), joinPath(field, strconv.Itoa(i)))...)
+		}
+		return errs
+	case typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			break
+		}
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			errs = append(errs, typeErrors(obj[key], typ.Elem(), joinPath(field, key))...)
+		}
+		return errs
+	}
+	if e, ok := json.Unmarshal(data, reflect.New(typ).Interface()).(*json.UnmarshalTypeError); ok {
+		e.Field = field
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// lessIndex 按结构体字段下标比较字段顺序,匿名嵌入结构体的字段下标包含上级下标
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// typeErrorField 按 json 字段路径查找结构体字段 eg: addrs.1.street → addrs[1].street, jobs.k.id → jobs[k].id
+// 路径中的字段不存在时,使用已找到的上级字段
+func (v *Validator) typeErrorField(e *json.UnmarshalTypeError, typ reflect.Type) *Field {
+	field := &Field{
+		Sf:   &reflect.StructField{Type: interfaceType},
+		Tags: &Tag{tag: typeTagPrefix + jsonKind(e.Type), param: e.Value, isHaveErr: true},
+	}
+	var path, structPath string
+	segments := strings.Split(e.Field, pathSeparator)
+walk:
+	for i := 0; i < len(segments) && segments[i] != blank; i++ {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		seg := segments[i]
+		switch typ.Kind() {
+		case reflect.Struct:
+			sf, ok := lookupJSONField(jsonFields(typ), seg)
+			if !ok {
+				break walk
+			}
+			path, structPath = joinPath(path, seg), joinPath(structPath, sf.Name)
+			field.Sf, field.JSONName, field.AliasName = &sf, jsonName(&sf), v.fieldAlias(&sf)
+			typ = sf.Type
+		case reflect.Slice, reflect.Array, reflect.Map:
+			path, structPath = path+"["+seg+"]", structPath+"["+seg+"]"
+			typ = typ.Elem()
+		default:
+			break walk
+		}
+	}
+	field.Path, field.StructPath = path, structPath
+	return field
+}
+
+// fieldAlias 字段别名,未设置 desc 时为 json 标签名
+func (v *Validator) fieldAlias(sf *reflect.StructField) string {
+	if desc := sf.Tag.Get(v.GetConfig().FieldDescribeTag); desc != blank {
+		return desc
+	}
+	return jsonName(sf)
+}
+
+// jsonKind 结构体字段类型对应的 json 值类型
+func jsonKind(typ reflect.Type) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	}
+	return "invalid"
+}
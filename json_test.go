@@ -0,0 +1,202 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type jsonAddress struct {
+	Street string `json:"street" validate:"required" desc:"街道"`
+}
+
+type jsonForm struct {
+	Name  string        `json:"name" validate:"omitempty,min=1,max=5" desc:"姓名"`
+	Age   int           `json:"age" validate:"required" desc:"年龄"`
+	Addrs []jsonAddress `json:"addrs" validate:"dive"`
+	Job   *jsonAddress  `json:"job" validate:"omitempty"`
+}
+
+func TestBindingJSONPresence(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"zero value present", `{"age":0}`, nil},
+		{"case-insensitive key", `{"AGE":0}`, nil},
+		{"nested case-insensitive key", `{"age":1,"Addrs":[{"STREET":""}]}`, nil},
+		{"missing", `{}`, []string{"age:required"}},
+		{"null", `{"age":null}`, []string{"age:required"}},
+		{"nested element", `{"age":1,"addrs":[{"street":""},{}]}`, []string{"addrs[1].street:required"}},
+		{"omitempty present", `{"age":1,"name":""}`, []string{"name:min"}},
+		{"omitempty missing", `{"age":1,"job":null}`, nil},
+	}
+	v := New()
+	v.GetConfig().CollectAll = true
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var form jsonForm
+			assertErrors(t, v.BindingJSON([]byte(tt.data), &form), tt.want...)
+		})
+	}
+}
+
+type jsonItem struct {
+	Count    int    `json:"count"`
+	Price    int    `json:"price" validate:"required_with=Count"`
+	Note     string `json:"note" validate:"required_without=Count"`
+	Discount int    `json:"discount" validate:"required_with=Total"`
+}
+
+type jsonOrder struct {
+	Total int        `json:"total"`
+	Items []jsonItem `json:"items" validate:"dive"`
+}
+
+func TestBindingJSONPresenceCrossField(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"sibling zero value present", `{"items":[{"count":0}]}`, []string{"items[0].price:required_with"}},
+		{"sibling missing", `{"items":[{"price":1}]}`, []string{"items[0].note:required_without"}},
+		{"both zero value present", `{"items":[{"count":0,"price":0}]}`, nil},
+		{"ancestor zero value present", `{"total":0,"items":[{"count":1,"price":1}]}`, []string{"items[0].discount:required_with"}},
+	}
+	v := New()
+	v.GetConfig().CollectAll = true
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertErrors(t, v.BindingJSON([]byte(tt.data), &jsonOrder{}), tt.want...)
+		})
+	}
+	// Binding 仍按零值判断
+	assertErrors(t, v.Binding(&jsonOrder{Items: []jsonItem{{}}}), "items[0].note:required_without")
+}
+
+func TestBindingJSONDecode(t *testing.T) {
+	v := New()
+	var form jsonForm
+	res := v.BindingJSON([]byte(`{"age":0,"name":"abcdef","job":{"street":"s"}}`), &form)
+	assertMessage(t, res, "姓名长度不超过5个字符")
+	if form.Name != "abcdef" || form.Job == nil || form.Job.Street != "s" {
+		t.Fatalf("form = %+v", form)
+	}
+	// 普通验证仍按零值判断
+	assertMessage(t, v.Binding(&jsonForm{}), "年龄为必填字段")
+	if res := v.BindingJSON([]byte(`{"age":`), &form); res.Error() == nil {
+		t.Fatal("invalid json should fail")
+	}
+}
+
+type jsonTime struct {
+	Name string `json:"name"`
+}
+
+func (t *jsonTime) UnmarshalJSON([]byte) error { return nil }
+
+type jsonUnknownForm struct {
+	jsonAddress
+	Age   int                    `json:"age" validate:"required" desc:"年龄"`
+	Addrs []jsonAddress          `json:"addrs" validate:"dive"`
+	Jobs  map[string]jsonAddress `json:"jobs"`
+	At    jsonTime               `json:"at"`
+}
+
+func TestBindingJSONUnknownFields(t *testing.T) {
+	v := New()
+	v.GetConfig().DisallowUnknownFields = true
+	var form jsonUnknownForm
+	assertErrors(t, v.BindingJSON([]byte(`{"street":"s","age":1,"addrs":[{"street":"a"}],"at":{"x":1}}`), &form))
+
+	// 未开启 CollectAll 时只返回第一个未知字段且不再验证
+	res := v.BindingJSON([]byte(`{"y":2,"x":1}`), &form)
+	assertErrors(t, res, "x:unknown_field")
+	assertMessage(t, res, "x为未知字段")
+	if fe := res.Errors()[0]; fe.Value != json.Number("1") || fe.StructPath != "x" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+
+	v.GetConfig().CollectAll = true
+	res = v.BindingJSON([]byte(`{"street":"s","addrs":[{"street":"a"},{"street":"b","zip":1}],"jobs":{"k":{"zip":2}}}`), &form)
+	assertErrors(t, res, "addrs[1].zip:unknown_field", "jobs[k].zip:unknown_field", "age:required")
+	if fe := res.Errors()[0]; fe.StructPath != "Addrs[1].zip" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+}
+
+func TestBindingJSONTypeErrors(t *testing.T) {
+	v := New()
+	var form jsonForm
+	res := v.BindingJSON([]byte(`{"age":"abc","name":"abcdef"}`), &form)
+	assertErrors(t, res, "age:type_number")
+	assertMessage(t, res, "年龄必须是数字")
+	if fe := res.Errors()[0]; fe.Param != "string" || fe.StructPath != "Age" || fe.JSONName != "age" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+
+	res = v.BindingJSON([]byte(`{"age":1,"addrs":[{"street":"a"},{"street":1}]}`), &form)
+	assertErrors(t, res, "addrs[1].street:type_string")
+	assertMessage(t, res, "街道必须是字符串")
+	if fe := res.Errors()[0]; fe.StructPath != "Addrs[1].Street" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+
+	en := WithLocale(context.Background(), LocaleEn)
+	assertMessage(t, v.BindingJSONContext(en, []byte(`{"age":1,"job":"x"}`), &form), "job must be an object")
+
+	res = v.BindingJSON([]byte(`{"age":1`), &form)
+	assertErrors(t, res, ":json_syntax")
+	assertMessage(t, res, "JSON格式错误,位置8")
+	if fe := res.Errors()[0]; fe.Param != "8" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+	assertMessage(t, v.BindingJSONContext(en, []byte(`{"age":1`), &form), "Invalid JSON at offset 8")
+}
+
+type jsonMapForm struct {
+	Name string                 `json:"name" validate:"required" desc:"姓名"`
+	Jobs map[string]jsonAddress `json:"jobs" validate:"dive"`
+	Ids  []int                  `json:"ids"`
+}
+
+// map 的值为结构体时路径包含 map key
+func TestBindingJSONTypeErrorMapPath(t *testing.T) {
+	v := New()
+	res := v.BindingJSON([]byte(`{"name":"a","jobs":{"k":5}}`), &jsonMapForm{})
+	assertErrors(t, res, "jobs[k]:type_object")
+	if fe := res.Errors()[0]; fe.StructPath != "Jobs[k]" || fe.Field != "Jobs" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+	assertErrors(t, v.BindingJSON([]byte(`{"name":"a","jobs":{"k":{"street":1}}}`), &jsonMapForm{}), "jobs[k].street:type_string")
+
+	// 开启 CollectAll 时继续验证其他字段
+	v.GetConfig().CollectAll = true
+	assertErrors(t, v.BindingJSON([]byte(`{"jobs":{"k":5,"m":{}}}`), &jsonMapForm{}), "jobs[k]:type_object", "name:required", "jobs[m].street:required")
+}
+
+// 开启 CollectAll 时类型错误的字段跳过,继续验证其他字段
+func TestBindingJSONTypeErrorCollectAll(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	var form jsonForm
+	res := v.BindingJSON([]byte(`{"age":"abc","name":"abcdef"}`), &form)
+	assertErrors(t, res, "age:type_number", "name:max")
+	assertMessage(t, res, "年龄必须是数字; 姓名长度不超过5个字符")
+
+	// 返回全部类型错误,类型错误的字段不视为存在
+	res = v.BindingJSON([]byte(`{"name":5,"age":"abc","addrs":[{"street":1},{},{"street":true}]}`), &jsonForm{})
+	assertErrors(t, res, "name:type_string", "age:type_number", "addrs[0].street:type_string", "addrs[2].street:type_string", "addrs[1].street:required")
+	assertMessage(t, res, "姓名必须是字符串; 年龄必须是数字; 街道必须是字符串; 街道必须是字符串; 街道为必填字段")
+	if fe := res.Errors()[3]; fe.Param != "bool" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+
+	// 类型错误字段的下级字段不再验证
+	assertErrors(t, v.BindingJSON([]byte(`{"age":1,"job":"x"}`), &jsonForm{}), "job:type_object")
+	assertErrors(t, v.BindingJSON([]byte(`{"age":1,"addrs":{"street":""}}`), &jsonForm{}), "addrs:type_array")
+	// 格式错误时不再验证
+	assertErrors(t, v.BindingJSON([]byte(`{"name":"abcdef"`), &jsonForm{}), ":json_syntax")
+}
@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type localeCtxKey struct{}
+
+// WithLocale 在 ctx 中设置错误信息语言,locale 可以是语言标识或 Accept-Language eg: zh-TW, en;q=0.8
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeCtxKey{}, locale)
+}
+
+// LocaleFromContext 获取 ctx 中设置的错误信息语言
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeCtxKey{}).(string)
+	return locale, ok && locale != blank
+}
+
+// TranslatorFor 按 Accept-Language 选择翻译器,按权重从高到低依次匹配
+// 每个语言标识依次匹配 zh-Hant-TW → zh-Hant → zh,全部未匹配时使用默认翻译器
+func (v *Validator) TranslatorFor(acceptLanguage string) Translator {
+	t, _ := v.negotiateLocale(acceptLanguage)
+	return t
+}
+
+// negotiateLocale 按 Accept-Language 选择翻译器,同时返回匹配的完整语言标识 eg: zh-TW 匹配 zh 翻译器时返回 zh-tw
+// 全部未匹配时返回默认翻译器及其语言标识
+func (v *Validator) negotiateLocale(acceptLanguage string) (Translator, string) {
+	for _, requested := range parseAcceptLanguage(acceptLanguage) {
+		for locale := requested; ; {
+			if t, ok := v.GetTranslatorByLocale(locale); ok {
+				return t, requested
+			}
+			idx := strings.LastIndex(locale, "-")
+			if idx <= 0 {
+				break
+			}
+			locale = locale[:idx]
+		}
+	}
+	t := v.GetTranslator()
+	return t, t.Locale()
+}
+
+// canonicalLocale 按 BCP 47 习惯转换语言标识大小写 eg: zh-hant-tw → zh-Hant-TW
+func canonicalLocale(locale string) string {
+	parts := strings.Split(strings.ToLower(locale), "-")
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// parseAcceptLanguage 解析 Accept-Language,按权重从高到低返回语言标识
+// eg: "zh-TW,zh;q=0.9,en;q=0.8" => [zh-tw zh en]
+func parseAcceptLanguage(acceptLanguage string) []string {
+	type language struct {
+		locale string
+		q      float64
+	}
+	var languages []language
+	for _, part := range strings.Split(acceptLanguage, tagSeparator) {
+		vals := strings.Split(part, ";")
+		locale := strings.ToLower(strings.Replace(strings.TrimSpace(vals[0]), "_", "-", -1))
+		if locale == blank || locale == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range vals[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if f, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		languages = append(languages, language{locale: locale, q: q})
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].q > languages[j].q
+	})
+	locales := make([]string, 0, len(languages))
+	for _, l := range languages {
+		locales = append(locales, l.locale)
+	}
+	return locales
+}
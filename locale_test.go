@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := map[string][]string{
+		"zh-TW,zh;q=0.9,en;q=0.8":  {"zh-tw", "zh", "en"},
+		"en;q=0.5, zh_CN;q=0.9, *": {"zh-cn", "en"},
+		"fr;q=0,en":                {"en"},
+		"":                         {},
+	}
+	for header, want := range tests {
+		if got := parseAcceptLanguage(header); !reflect.DeepEqual(got, want) {
+			t.Errorf("parseAcceptLanguage(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestTranslatorFor(t *testing.T) {
+	v := New()
+	tests := map[string]string{
+		"en-US,en;q=0.9":       LocaleEn,
+		"fr,en;q=0.5,zh;q=0.8": LocaleZh,
+		"zh-Hant-TW":           LocaleZh,
+		"EN_gb":                LocaleEn,
+		"fr":                   LocaleZh,
+		"":                     LocaleZh,
+	}
+	for header, want := range tests {
+		if got := v.TranslatorFor(header).Locale(); got != want {
+			t.Errorf("TranslatorFor(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
+
+func TestCanonicalLocale(t *testing.T) {
+	tests := map[string]string{
+		"zh-tw":      "zh-TW",
+		"ZH-HANT-TW": "zh-Hant-TW",
+		"en":         "en",
+		"es-419":     "es-419",
+	}
+	for locale, want := range tests {
+		if got := canonicalLocale(locale); got != want {
+			t.Errorf("canonicalLocale(%q) = %s, want %s", locale, got, want)
+		}
+	}
+}
+
+func TestBindingLocale(t *testing.T) {
+	v := New()
+	assertMessage(t, v.BindingLocale(&orForm{}, "en-US,en;q=0.9"), "联系方式 is a required field")
+	assertMessage(t, v.BindingLocale(&orForm{}, "fr"), "联系方式为必填字段")
+	ctx := WithLocale(context.Background(), LocaleEn)
+	if locale, ok := LocaleFromContext(ctx); !ok || locale != LocaleEn {
+		t.Fatalf("LocaleFromContext = %s, %v", locale, ok)
+	}
+	assertMessage(t, v.BindingContext(ctx, &orForm{}), "联系方式 is a required field")
+	// 默认翻译器不受影响
+	assertMessage(t, v.Binding(&orForm{}), "联系方式为必填字段")
+	if _, ok := LocaleFromContext(WithLocale(context.Background(), "")); ok {
+		t.Fatal("empty locale found in context")
+	}
+}
@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+type valueCtxKey struct{}
+
+// ErrorRenderer 验证失败时写入响应,err 为 Result.Error()
+type ErrorRenderer func(w http.ResponseWriter, r *http.Request, status int, err error)
+
+// HandlerConfig Handler 验证失败时的响应配置
+type HandlerConfig struct {
+	StatusCode int           //验证失败时的状态码,默认 400
+	Render     ErrorRenderer //验证失败时写入响应,默认 RenderJSONError
+}
+
+// ErrorResponse RenderJSONError 的响应格式
+type ErrorResponse struct {
+	Message string       `json:"message"`
+	Errors  []ErrorField `json:"errors,omitempty"`
+}
+
+// ErrorField ErrorResponse 中单个字段的错误信息
+type ErrorField struct {
+	Field   string `json:"field"` //json 完整路径 eg: addresses[1].street
+	Tag     string `json:"tag"`
+	Code    int    `json:"code"`
+	OrCodes []int  `json:"or_codes,omitempty"` //多个验证方法全部失败时每个验证方法的错误码
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Handler 按 BindRequest 解析并验证请求参数,验证通过后调用 next
+// proto 为参数结构体或其指针,每个请求创建新的实例,next 中通过 ValueFromContext 获取 eg: *UserForm
+// 验证失败时按 HandlerConfig 写入响应,错误信息语言按 Accept-Language 选择
+func (v *Validator) Handler(proto interface{}, next http.Handler, conf ...*HandlerConfig) http.Handler {
+	typ := reflect.TypeOf(proto)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic(errors.New(mustStruct))
+	}
+	c := &HandlerConfig{StatusCode: http.StatusBadRequest, Render: RenderJSONError}
+	if len(conf) > 0 && conf[0] != nil {
+		if conf[0].StatusCode != 0 {
+			c.StatusCode = conf[0].StatusCode
+		}
+		if conf[0].Render != nil {
+			c.Render = conf[0].Render
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		obj := reflect.New(typ).Interface()
+		if err := v.BindRequest(r, obj).Error(); err != nil {
+			c.Render(w, r, c.StatusCode, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), valueCtxKey{}, obj)))
+	})
+}
+
+// ValueFromContext 获取 Handler 验证通过的参数,类型为 proto 结构体的指针
+func ValueFromContext(ctx context.Context) (interface{}, bool) {
+	obj := ctx.Value(valueCtxKey{})
+	return obj, obj != nil
+}
+
+// RenderJSONError 以 ErrorResponse 格式写入验证失败响应
+func RenderJSONError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	resp := ErrorResponse{Message: err.Error()}
+	var ve ValidationErrors
+	if errors.As(err, &ve) {
+		for _, fe := range ve {
+			resp.Errors = append(resp.Errors, ErrorField{Field: fe.Path, Tag: fe.Tag, Code: fe.Code, OrCodes: fe.OrCodes, Param: fe.Param, Message: fe.Message})
+		}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type handlerForm struct {
+	Name string `json:"name" validate:"required,max=5" desc:"姓名" desc_en:"Name"`
+	Age  int    `json:"age" validate:"omitempty,gte=18" desc:"年龄"`
+}
+
+func serve(h http.Handler, body string, lang string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	if lang != "" {
+		r.Header.Set("Accept-Language", lang)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	var got *handlerForm
+	h := v.Handler(handlerForm{}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		obj, ok := ValueFromContext(r.Context())
+		if !ok {
+			t.Fatal("ValueFromContext not set")
+		}
+		got = obj.(*handlerForm)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	w := serve(h, `{"name":"abc","age":20}`, "")
+	if w.Code != http.StatusNoContent || got == nil || got.Name != "abc" || got.Age != 20 {
+		t.Fatalf("code = %d, value = %+v", w.Code, got)
+	}
+
+	got = nil
+	w = serve(h, `{"name":"abcdef","age":1}`, "")
+	if w.Code != http.StatusBadRequest || got != nil {
+		t.Fatalf("code = %d, next called = %v", w.Code, got != nil)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Fatalf("Content-Type = %s", ct)
+	}
+	want := `{"message":"姓名长度不超过5个字符; 年龄必须大于或等于18","errors":[` +
+		`{"field":"name","tag":"max","code":10008,"param":"5","message":"姓名长度不超过5个字符"},` +
+		`{"field":"age","tag":"gte","code":10012,"param":"18","message":"年龄必须大于或等于18"}]}` + "\n"
+	if w.Body.String() != want {
+		t.Fatalf("body = %s", w.Body.String())
+	}
+
+	w = serve(h, `{}`, "en")
+	if w.Body.String() != `{"message":"Name is a required field","errors":[{"field":"name","tag":"required","code":10001,"message":"Name is a required field"}]}`+"\n" {
+		t.Fatalf("body = %s", w.Body.String())
+	}
+	// json 格式错误同样按验证失败响应
+	w = serve(h, `{"name":1`, "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"tag":"json_syntax"`) {
+		t.Fatalf("code = %d, body = %s", w.Code, w.Body.String())
+	}
+	if _, ok := ValueFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); ok {
+		t.Fatal("ValueFromContext should be empty outside Handler")
+	}
+}
+
+func TestHandlerConfig(t *testing.T) {
+	v := New()
+	var rendered error
+	h := v.Handler(&handlerForm{}, http.NotFoundHandler(), &HandlerConfig{
+		StatusCode: http.StatusUnprocessableEntity,
+		Render: func(w http.ResponseWriter, r *http.Request, status int, err error) {
+			rendered = err
+			w.WriteHeader(status)
+		},
+	})
+	w := serve(h, `{}`, "")
+	var ve ValidationErrors
+	if w.Code != http.StatusUnprocessableEntity || !errors.As(rendered, &ve) || ve[0].Tag != "required" {
+		t.Fatalf("code = %d, err = %v", w.Code, rendered)
+	}
+	// 未设置的配置使用默认值
+	w = serve(v.Handler(&handlerForm{}, http.NotFoundHandler(), &HandlerConfig{}), `{}`, "")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("code = %d", w.Code)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Handler with non-struct proto should panic")
+		}
+	}()
+	v.Handler(1, http.NotFoundHandler())
+}
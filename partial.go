@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"context"
+	"strings"
+)
+
+// fieldFilter 按结构体字段路径筛选需要验证的字段 eg: Job.City.CityId
+type fieldFilter struct {
+	except  bool                //true 时排除 fields 中的字段
+	fields  map[string]struct{} //指定的字段路径
+	parents map[string]struct{} //指定字段的上级路径,只遍历下级字段
+}
+
+// newFieldFilter 创建字段筛选条件
+func newFieldFilter(fields []string, except bool) *fieldFilter {
+	f := &fieldFilter{
+		except:  except,
+		fields:  make(map[string]struct{}, len(fields)),
+		parents: make(map[string]struct{}),
+	}
+	for _, field := range fields {
+		field = stripIndexPath(field)
+		f.fields[field] = struct{}{}
+		for idx := strings.LastIndex(field, pathSeparator); idx > 0; idx = strings.LastIndex(field, pathSeparator) {
+			field = field[:idx]
+			f.parents[field] = struct{}{}
+		}
+	}
+	return f
+}
+
+// match 返回字段是否需要验证,及是否需要遍历下级字段
+func (f *fieldFilter) match(structPath string) (check, traverse bool) {
+	if f == nil {
+		return true, true
+	}
+	structPath = stripIndexPath(structPath)
+	if f.except {
+		_, ok := f.fields[structPath]
+		return !ok, !ok
+	}
+	// 字段或上级字段已指定时,验证全部下级字段
+	for path := structPath; ; {
+		if _, ok := f.fields[path]; ok {
+			return true, true
+		}
+		idx := strings.LastIndex(path, pathSeparator)
+		if idx <= 0 {
+			break
+		}
+		path = path[:idx]
+	}
+	_, ok := f.parents[structPath]
+	return false, ok
+}
+
+// named 返回字段是否由 StructPartial 指定,或为指定字段的上级字段
+func (f *fieldFilter) named(structPath string) bool {
+	if f == nil || f.except {
+		return false
+	}
+	structPath = stripIndexPath(structPath)
+	if _, ok := f.fields[structPath]; ok {
+		return true
+	}
+	_, ok := f.parents[structPath]
+	return ok
+}
+
+// stripIndexPath 去掉路径中的下标及 map key eg: Addresses[1].Street → Addresses.Street
+func stripIndexPath(path string) string {
+	if !strings.Contains(path, "[") {
+		return path
+	}
+	var b strings.Builder
+	depth := 0
+	for _, r := range path {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// StructPartial 只验证指定的字段,字段为结构体字段路径 eg: Name, Job.City.CityId, Addresses.Street
+// 指定的字段为结构体时验证其全部下级字段
+func (v *Validator) StructPartial(obj interface{}, fields ...string) *Result {
+	return v.StructPartialContext(context.Background(), obj, fields...)
+}
+
+// StructPartialContext 同 StructPartial,使用 ctx 中设置的语言及场景
+func (v *Validator) StructPartialContext(ctx context.Context, obj interface{}, fields ...string) *Result {
+	vd := v.newValidate(ctx)
+	vd.filter = newFieldFilter(fields, false)
+	return vd.binding(obj)
+}
+
+// StructExcept 验证除指定字段外的全部字段,字段路径格式与 StructPartial 一致
+func (v *Validator) StructExcept(obj interface{}, fields ...string) *Result {
+	return v.StructExceptContext(context.Background(), obj, fields...)
+}
+
+// StructExceptContext 同 StructExcept,使用 ctx 中设置的语言及场景
+func (v *Validator) StructExceptContext(ctx context.Context, obj interface{}, fields ...string) *Result {
+	vd := v.newValidate(ctx)
+	vd.filter = newFieldFilter(fields, true)
+	return vd.binding(obj)
+}
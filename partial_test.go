@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"context"
+	"testing"
+)
+
+type partialCity struct {
+	CityId int    `json:"city_id" validate:"required" desc:"城市ID"`
+	Name   string `json:"name" validate:"required" desc:"城市名"`
+}
+
+type partialJob struct {
+	Title string       `json:"title" validate:"required" desc:"职位"`
+	City  *partialCity `json:"city" validate:"required"`
+}
+
+type partialForm struct {
+	Name      string           `json:"name" validate:"required" desc:"姓名"`
+	Job       *partialJob      `json:"job" validate:"required"`
+	Addresses []collectAddress `json:"addresses" validate:"required,dive"`
+}
+
+func TestStructPartial(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	form := &partialForm{
+		Job:       &partialJob{City: &partialCity{}},
+		Addresses: []collectAddress{{Street: "a"}, {}},
+	}
+	assertErrors(t, v.StructPartial(form, "Name"), "name:required")
+	// 只验证指定的下级字段,上级字段仅遍历
+	res := v.StructPartial(form, "Job.City.CityId")
+	assertErrors(t, res, "job.city.city_id:required")
+	assertMessage(t, res, "城市ID为必填字段")
+	// 指定结构体时验证其全部下级字段
+	assertErrors(t, v.StructPartial(form, "Job.City"), "job.city:required", "job.city.city_id:required", "job.city.name:required")
+	// 路径中的下标会被忽略
+	assertErrors(t, v.StructPartial(form, "Addresses[1].Street"), "addresses[1].street:required")
+	assertErrors(t, v.StructPartial(form, "Name", "Job.Title"), "name:required", "job.title:required")
+	assertErrors(t, v.StructPartial(form, "Unknown"))
+}
+
+func TestStructExcept(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	form := &partialForm{Job: &partialJob{City: &partialCity{}}}
+	assertErrors(t, v.StructExcept(form, "Name", "Job.City"), "job.title:required", "addresses:required")
+	assertErrors(t, v.StructExcept(form, "Job.City.Name", "Addresses"), "name:required", "job.title:required", "job.city:required", "job.city.city_id:required")
+
+	en := WithLocale(context.Background(), LocaleEn)
+	assertMessage(t, v.StructExceptContext(en, form, "Job", "Addresses"), "姓名 is a required field")
+	assertMessage(t, v.StructPartialContext(en, form, "Job.Title"), "职位 is a required field")
+}
+
+type partialPlainForm struct {
+	Name string     `json:"name" validate:"required" desc:"姓名"`
+	Job  partialJob `json:"job"`
+}
+
+// 未设置验证标签的嵌套字段只在 StructPartial 指定时遍历
+func TestStructPartialUntagged(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	form := &partialPlainForm{Job: partialJob{City: &partialCity{CityId: 1}}}
+	assertErrors(t, v.Binding(form), "name:required")
+	assertErrors(t, v.StructExcept(form, "Name"))
+	res := v.StructPartial(form, "Job.Title")
+	assertErrors(t, res, "job.title:required")
+	assertMessage(t, res, "职位为必填字段")
+	assertErrors(t, v.StructPartial(form, "Job"), "job.title:required", "job.city.name:required")
+	assertErrors(t, v.StructPartial(form, "Job.City.Name"), "job.city.name:required")
+}
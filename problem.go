@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Problem RFC 7807 问题详情 application/problem+json
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"` //验证失败的字段
+}
+
+// InvalidParam Problem 中单个字段的验证失败信息
+type InvalidParam struct {
+	Name    string `json:"name"`               //json 完整路径 eg: addresses[1].street
+	Pointer string `json:"pointer"`            //JSON Pointer eg: /addresses/1/street
+	Rule    string `json:"rule"`               //验证失败的tag eg: required
+	Code    int    `json:"code"`               //验证方法的错误码 eg: 10001
+	OrCodes []int  `json:"or_codes,omitempty"` //多个验证方法全部失败时每个验证方法的错误码
+	Reason  string `json:"reason"`             //翻译后的错误信息
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// NewProblem 由 Result.Error() 生成问题详情,ValidationErrors 中的每个字段对应一个 InvalidParam
+func NewProblem(status int, err error) *Problem {
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+	}
+	var ve ValidationErrors
+	if errors.As(err, &ve) {
+		for _, fe := range ve {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{
+				Name:    fe.Path,
+				Pointer: jsonPointer(fe.Path),
+				Rule:    fe.Tag,
+				Code:    fe.Code,
+				OrCodes: fe.OrCodes,
+				Reason:  fe.Message,
+			})
+		}
+	}
+	return p
+}
+
+// RenderProblem 以 application/problem+json 格式写入验证失败响应,可作为 HandlerConfig.Render 使用
+func RenderProblem(w http.ResponseWriter, r *http.Request, status int, err error) {
+	p := NewProblem(status, err)
+	p.Instance = r.URL.Path
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// jsonPointer json 路径转换为 RFC 6901 JSON Pointer eg: addresses[1].street → /addresses/1/street
+func jsonPointer(path string) string {
+	var b strings.Builder
+	for _, seg := range splitFormKey(path) {
+		b.WriteString("/")
+		b.WriteString(pointerEscaper.Replace(seg))
+	}
+	return b.String()
+}
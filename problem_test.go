@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type problemForm struct {
+	Labels map[string]string `json:"labels" validate:"dive,max=3" desc:"标签"`
+	Addrs  []collectAddress  `json:"addrs" validate:"required,dive"`
+}
+
+func TestNewProblem(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	err := v.Binding(&problemForm{
+		Labels: map[string]string{"a/b": "abcd", "c~d": "abcd"},
+		Addrs:  []collectAddress{{Street: "a"}, {}},
+	}).Error()
+	p := NewProblem(http.StatusUnprocessableEntity, err)
+	if p.Type != "about:blank" || p.Title != "Unprocessable Entity" || p.Status != 422 || p.Detail != err.Error() {
+		t.Fatalf("problem = %+v", p)
+	}
+	want := []InvalidParam{
+		{Name: "labels[a/b]", Pointer: "/labels/a~1b", Rule: "max", Code: 10008, Reason: "标签长度不超过3个字符"},
+		{Name: "labels[c~d]", Pointer: "/labels/c~0d", Rule: "max", Code: 10008, Reason: "标签长度不超过3个字符"},
+		{Name: "addrs[1].street", Pointer: "/addrs/1/street", Rule: "required", Code: 10001, Reason: "街道为必填字段"},
+	}
+	if !reflect.DeepEqual(p.InvalidParams, want) {
+		t.Fatalf("invalid-params = %+v", p.InvalidParams)
+	}
+
+	p = NewProblem(http.StatusBadRequest, errors.New("Object Must Struct"))
+	if p.Detail != "Object Must Struct" || p.InvalidParams != nil {
+		t.Fatalf("problem = %+v", p)
+	}
+}
+
+func TestRenderProblem(t *testing.T) {
+	v := New()
+	h := v.Handler(&handlerForm{}, http.NotFoundHandler(), &HandlerConfig{Render: RenderProblem})
+	r := httptest.NewRequest(http.MethodPost, "/users", nil)
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest || w.Header().Get("Content-Type") != "application/problem+json; charset=utf-8" {
+		t.Fatalf("code = %d, Content-Type = %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"type": "about:blank", "title": "Bad Request", "status": float64(400), "detail": "姓名为必填字段", "instance": "/users",
+		"invalid-params": []interface{}{map[string]interface{}{"name": "name", "pointer": "/name", "rule": "required", "code": float64(10001), "reason": "姓名为必填字段"}},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Fatalf("body = %s", w.Body.String())
+	}
+}
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// BindRequest 按请求方法及 Content-Type 解析参数填充 obj 后验证
+// query 参数及 header 标签的字段始终解析,POST/PUT/PATCH 等请求再按 Content-Type 解析请求体:
+// application/json, application/x-www-form-urlencoded, multipart/form-data
+// 错误信息语言依次使用 ctx 中 WithLocale 设置的语言、Accept-Language
+// 同名参数依次使用请求体、query、header 中的值,非 json 请求时字段路径及别名依次使用 form、json 标签名
+// json 请求体默认按零值判断 required,开启 Config.JSONPresence 后与 BindingJSON 一致按字段是否存在判断
+// 请求体全部读入内存,需要限制大小时先使用 http.MaxBytesReader eg: r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+func (v *Validator) BindRequest(r *http.Request, obj interface{}) *Result {
+	ctx := r.Context()
+	if _, ok := LocaleFromContext(ctx); !ok {
+		ctx = WithLocale(ctx, r.Header.Get("Accept-Language"))
+	}
+	return v.BindRequestContext(ctx, r, obj)
+}
+
+// BindRequestContext 同 BindRequest,使用 ctx 中设置的语言及场景
+func (v *Validator) BindRequestContext(ctx context.Context, r *http.Request, obj interface{}) *Result {
+	vd := v.newValidate(ctx)
+	value := reflect.ValueOf(obj)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return vd.res.SetError(errors.New(mustStruct))
+	}
+	current := value.Elem()
+	// 除 json 请求外字段路径及别名使用 form 标签名
+	vd.form = true
+	// query 及 header 参数
+	bindParams := func() bool {
+		isHaveErr := vd.bindHeader(current, r.Header, blank, blank)
+		if isHaveErr && !v.GetConfig().CollectAll {
+			return true
+		}
+		return vd.bindForm(current, r.URL.Query()) || isHaveErr
+	}
+	if !hasRequestBody(r) {
+		if bindParams() {
+			return vd.translateFields()
+		}
+		return vd.binding(obj)
+	}
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case contentType == "application/json" || strings.HasSuffix(contentType, "+json"):
+		vd.form = false
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return vd.res.SetError(err)
+		}
+		return vd.bindingJSON(data, obj, v.GetConfig().JSONPresence, bindParams)
+	case contentType == "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return vd.res.SetError(err)
+		}
+		if vd.bindBody(current, bindParams, r.PostForm) {
+			return vd.translateFields()
+		}
+	case contentType == "multipart/form-data":
+		if err := r.ParseMultipartForm(defaultMultipartMemory); err != nil {
+			return vd.res.SetError(err)
+		}
+		if vd.bindBody(current, bindParams, r.MultipartForm.Value) {
+			return vd.translateFields()
+		}
+		vd.bindFormFiles(current, r.MultipartForm.File)
+	case contentType == blank:
+		if bindParams() {
+			return vd.translateFields()
+		}
+	default:
+		return vd.res.SetError(fmt.Errorf(unsupportedMedia, contentType))
+	}
+	return vd.binding(obj)
+}
+
+// bindBody 填充 query、header 参数后填充请求体参数,请求体中的同名字段覆盖
+func (v *validate) bindBody(current reflect.Value, bindParams func() bool, values map[string][]string) bool {
+	isHaveErr := bindParams()
+	if isHaveErr && !v.v.GetConfig().CollectAll {
+		return true
+	}
+	return v.bindForm(current, values) || isHaveErr
+}
+
+// hasRequestBody 请求是否需要解析请求体
+func hasRequestBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return false
+	}
+	return r.Body != nil && r.Body != http.NoBody
+}
+
+// bindHeader 按 header 标签填充字段 eg: `header:"X-Request-Id"`,遍历非指针的嵌套结构体
+// 返回是否有参数转换失败,未开启 CollectAll 时遇到第一个错误立即返回
+func (v *validate) bindHeader(current reflect.Value, header http.Header, path, structPath string) bool {
+	isHaveErr := false
+	typ := current.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.PkgPath != blank {
+			continue
+		}
+		fieldPath, fieldStructPath := joinPath(path, v.fieldName(&sf)), joinPath(structPath, sf.Name)
+		if sf.Anonymous && sf.Tag.Get(jsonTag) == blank {
+			fieldPath = path
+		}
+		var field *Field
+		if name := sf.Tag.Get(headerTag); name != blank && name != skipValidationTag {
+			vals := header.Values(name)
+			if field = v.setFormValue(current.Field(i), &sf, fieldPath, fieldStructPath, vals); field == nil && len(vals) > 0 && v.presence != nil {
+				v.presence.add(fieldPath)
+			}
+		} else if sf.Type.Kind() == reflect.Struct && !reflect.PtrTo(sf.Type).Implements(textUnmarshalerType) {
+			if v.bindHeader(current.Field(i), header, fieldPath, fieldStructPath) {
+				if !v.v.GetConfig().CollectAll {
+					return true
+				}
+				isHaveErr = true
+			}
+			continue
+		}
+		if field == nil {
+			continue
+		}
+		v.res.fields = append(v.res.fields, field)
+		if !v.v.GetConfig().CollectAll {
+			return true
+		}
+		isHaveErr = true
+	}
+	return isHaveErr
+}
@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type requestAddress struct {
+	Street string `json:"street" validate:"required" desc:"街道"`
+}
+
+type requestForm struct {
+	RequestId string                `header:"X-Request-Id" json:"-" validate:"required" desc:"请求ID"`
+	Page      int                   `form:"page" json:"page" validate:"omitempty,min=1" desc:"页码"`
+	Age       int                   `json:"age" validate:"required" desc:"年龄" desc_en:"Age"`
+	Tags      []string              `form:"tags" json:"tags" validate:"omitempty,dive,max=3"`
+	Addrs     []requestAddress      `json:"addrs" validate:"dive"`
+	Avatar    *multipart.FileHeader `form:"avatar" json:"-"`
+}
+
+type requestPlainAddress struct {
+	Street string `form:"street" validate:"required"`
+}
+
+type requestPlainForm struct {
+	Name  string                `form:"name" validate:"required,min=2"`
+	Addrs []requestPlainAddress `form:"addrs" validate:"dive"`
+}
+
+func newRequest(method, target, contentType, body string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.Header.Set("X-Request-Id", "1")
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func TestBindRequestQuery(t *testing.T) {
+	v := New()
+	var form requestForm
+	assertErrors(t, v.BindRequest(newRequest(http.MethodGet, "/?page=2&age=1&tags=a&tags=b", "", ""), &form))
+	if form.RequestId != "1" || form.Page != 2 || form.Age != 1 || len(form.Tags) != 2 {
+		t.Fatalf("form = %+v", form)
+	}
+
+	res := v.BindRequest(newRequest(http.MethodGet, "/?page=abc&age=1", "", ""), &requestForm{})
+	assertErrors(t, res, "page:type_number")
+	assertMessage(t, res, "页码必须是数字")
+	if fe := res.Errors()[0]; fe.Value != "abc" || fe.StructPath != "Page" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+}
+
+func TestBindRequestJSON(t *testing.T) {
+	v := New()
+	// 默认与 Binding 一致按零值判断 required
+	assertErrors(t, v.BindRequest(newRequest(http.MethodPost, "/?page=1", "application/json", `{"age":0}`), &requestForm{}), "age:required")
+	assertMessage(t, v.BindRequest(newRequest(http.MethodPost, "/", "application/json", `{}`), &requestForm{}), "年龄为必填字段")
+	assertMessage(t, v.BindRequest(newRequest(http.MethodPost, "/", "application/problem+json", `{"age":"1"}`), &requestForm{}), "年龄必须是数字")
+	assertErrors(t, v.BindRequest(newRequest(http.MethodPost, "/?page=abc", "application/json", `{"age":1}`), &requestForm{}), "page:type_number")
+	assertMessage(t, v.BindRequest(newRequest(http.MethodPost, "/", "text/plain", "age=1"), &requestForm{}), "Unsupported Content-Type text/plain")
+	assertMessage(t, v.BindRequest(newRequest(http.MethodPost, "/", "application/json", `{}`), requestForm{}), mustStruct)
+
+	// 开启 JSONPresence 后与 BindingJSON 一致按字段是否存在判断
+	v.GetConfig().JSONPresence = true
+	assertErrors(t, v.BindRequest(newRequest(http.MethodPost, "/?page=1", "application/json", `{"age":0}`), &requestForm{}))
+	assertMessage(t, v.BindRequest(newRequest(http.MethodPost, "/", "application/json", `{}`), &requestForm{}), "年龄为必填字段")
+}
+
+func TestBindRequestBodyLimit(t *testing.T) {
+	v := New()
+	r := newRequest(http.MethodPost, "/", "application/json", `{"age":1,"tags":["a","b","c"]}`)
+	r.Body = http.MaxBytesReader(httptest.NewRecorder(), r.Body, 8)
+	res := v.BindRequest(r, &requestForm{})
+	assertMessage(t, res, "http: request body too large")
+	if len(res.Errors()) != 0 {
+		t.Fatalf("errors = %v", res.Errors())
+	}
+}
+
+func TestBindRequestForm(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	var form requestForm
+	res := v.BindRequest(newRequest(http.MethodPost, "/", "application/x-www-form-urlencoded", "age=1&tags[]=abcd&addrs[0][street]=a&addrs[1][street]="), &form)
+	assertErrors(t, res, "tags[0]:max", "addrs[1].street:required")
+	assertMessage(t, res, "tags长度不超过3个字符; 街道为必填字段")
+	if len(form.Addrs) != 2 || form.Addrs[0].Street != "a" {
+		t.Fatalf("form = %+v", form)
+	}
+	assertErrors(t, v.BindRequest(newRequest(http.MethodPost, "/", "application/x-www-form-urlencoded", "age=x&page=y"), &requestForm{}), "age:type_number", "page:type_number")
+
+	// 只有 form 标签的字段路径及别名使用 form 标签名
+	res = v.BindRequest(newRequest(http.MethodPost, "/", "application/x-www-form-urlencoded", "name=a&addrs[0][street]=x&addrs[1][street]="), &requestPlainForm{})
+	assertErrors(t, res, "name:min", "addrs[1].street:required")
+	assertMessage(t, res, "name长度必须至少为2个字符; street为必填字段")
+	if fe := res.Errors()[1]; fe.JSONName != "street" || fe.StructPath != "Addrs[1].Street" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+	assertErrors(t, v.BindRequest(newRequest(http.MethodGet, "/?addrs[0][street]=", "", ""), &requestPlainForm{}), "name:required", "addrs[0].street:required")
+
+	// 下标不连续时中间的元素为零值并照常验证
+	var plain requestPlainForm
+	res = v.BindRequest(newRequest(http.MethodPost, "/", "application/x-www-form-urlencoded", "name=ab&addrs[2][street]=x"), &plain)
+	assertErrors(t, res, "addrs[0].street:required", "addrs[1].street:required")
+	if len(plain.Addrs) != 3 || plain.Addrs[2].Street != "x" {
+		t.Fatalf("form = %+v", plain)
+	}
+}
+
+func TestBindRequestPrecedence(t *testing.T) {
+	v := New()
+	// 同名参数请求体优先于 query、header
+	var form requestForm
+	r := newRequest(http.MethodPost, "/?age=1&page=2", "application/json", `{"age":3}`)
+	assertErrors(t, v.BindRequest(r, &form))
+	if form.Age != 3 || form.Page != 2 {
+		t.Fatalf("form = %+v", form)
+	}
+	form = requestForm{}
+	assertErrors(t, v.BindRequest(newRequest(http.MethodPost, "/?age=1&page=2", "application/x-www-form-urlencoded", "age=3"), &form))
+	if form.Age != 3 || form.Page != 2 {
+		t.Fatalf("form = %+v", form)
+	}
+
+	type headerForm struct {
+		Token string `header:"X-Token" json:"token"`
+	}
+	var hf headerForm
+	r = newRequest(http.MethodPost, "/?token=b", "application/json", `{"token":"c"}`)
+	r.Header.Set("X-Token", "a")
+	assertErrors(t, v.BindRequest(r, &hf))
+	if hf.Token != "c" {
+		t.Fatalf("token = %s", hf.Token)
+	}
+	hf = headerForm{}
+	r = newRequest(http.MethodGet, "/?token=b", "", "")
+	r.Header.Set("X-Token", "a")
+	assertErrors(t, v.BindRequest(r, &hf))
+	if hf.Token != "b" {
+		t.Fatalf("token = %s", hf.Token)
+	}
+}
+
+func TestBindRequestMultipart(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("age", "3")
+	fw, _ := mw.CreateFormFile("avatar", "a.png")
+	_, _ = fw.Write([]byte("png"))
+	_ = mw.Close()
+
+	v := New()
+	var form requestForm
+	assertErrors(t, v.BindRequest(newRequest(http.MethodPut, "/", mw.FormDataContentType(), body.String()), &form))
+	if form.Age != 3 || form.Avatar == nil || form.Avatar.Filename != "a.png" {
+		t.Fatalf("form = %+v", form)
+	}
+}
+
+func TestBindRequestHeaderAndLocale(t *testing.T) {
+	v := New()
+	r := httptest.NewRequest(http.MethodGet, "/?page=1", nil)
+	r.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	assertMessage(t, v.BindRequest(r, &requestForm{}), "请求ID is a required field")
+	r.Header.Set("X-Request-Id", "1")
+	assertMessage(t, v.BindRequest(r, &requestForm{}), "Age is a required field")
+	assertMessage(t, v.BindRequestContext(WithLocale(r.Context(), LocaleZh), r, &requestForm{}), "年龄为必填字段")
+}
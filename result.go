@@ -0,0 +1,33 @@
+package validator
+
+// Result 单次验证的结果,每次 Binding 都会返回新的 Result
+type Result struct {
+	field  *Field
+	fields []*Field
+	err    error
+	errs   ValidationErrors
+}
+
+// GetField 第一个验证失败的字段
+func (r *Result) GetField() *Field {
+	return r.field
+}
+
+// GetFields 全部验证失败的字段,未开启 CollectAll 时最多只有一个
+func (r *Result) GetFields() []*Field {
+	return r.fields
+}
+
+func (r *Result) Error() error {
+	return r.err
+}
+
+func (r *Result) SetError(err error) *Result {
+	r.err = err
+	return r
+}
+
+// Errors 每个验证失败字段对应的结构化错误信息
+func (r *Result) Errors() ValidationErrors {
+	return r.errs
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"context"
+	"strings"
+)
+
+type sceneCtxKey struct{}
+
+// WithScene 在 ctx 中设置验证场景,只验证未指定场景及指定了该场景的规则 eg: create, update
+func WithScene(ctx context.Context, scene string) context.Context {
+	return context.WithValue(ctx, sceneCtxKey{}, scene)
+}
+
+// SceneFromContext 获取 ctx 中设置的验证场景
+func SceneFromContext(ctx context.Context) (string, bool) {
+	scene, ok := ctx.Value(sceneCtxKey{}).(string)
+	return scene, ok && scene != blank
+}
+
+// BindingScene 按场景验证结构体 eg: validate:"required@create,omitempty@update"
+func (v *Validator) BindingScene(obj interface{}, scene string) *Result {
+	return v.BindingContext(WithScene(context.Background(), scene), obj)
+}
+
+// filterSceneTags 按场景过滤验证规则
+// 未指定场景的规则始终保留,指定场景的规则只在场景匹配时保留并去掉场景后缀 eg: required@create@update
+func filterSceneTags(tags []string, scene string) []string {
+	filtered := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		vals := strings.Split(tag, sceneSeparator)
+		if len(vals) == 1 {
+			filtered = append(filtered, tag)
+			continue
+		}
+		for _, s := range vals[1:] {
+			if s == scene {
+				filtered = append(filtered, vals[0])
+				break
+			}
+		}
+	}
+	return filtered
+}
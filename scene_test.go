@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"context"
+	"testing"
+)
+
+type sceneUser struct {
+	Id       int    `json:"id" validate:"required@update@delete" desc:"ID"`
+	Password string `json:"password" validate:"required@create,omitempty@update,min=6" desc:"密码"`
+	Email    string `json:"email" validate:"omitempty,ne=0x40@create" desc:"邮箱"`
+}
+
+func TestBindingScene(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+
+	// 未设置场景时只验证未指定场景的规则
+	assertErrors(t, v.Binding(&sceneUser{}), "password:min")
+
+	res := v.BindingScene(&sceneUser{Email: "@"}, "create")
+	assertErrors(t, res, "password:required", "email:ne")
+	assertMessage(t, res, "密码为必填字段; 邮箱不能等于@")
+
+	assertErrors(t, v.BindingScene(&sceneUser{}, "update"), "id:required")
+	assertErrors(t, v.BindingScene(&sceneUser{Password: "123"}, "delete"), "id:required", "password:min")
+	assertErrors(t, v.BindingScene(&sceneUser{Id: 1, Password: "123456", Email: "a@b"}, "create"))
+
+	// 同一类型不同场景的规则分别缓存
+	assertErrors(t, v.BindingScene(&sceneUser{}, "create"), "password:required")
+	assertErrors(t, v.BindingScene(&sceneUser{}, "update"), "id:required")
+}
+
+func TestSceneContext(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	ctx := WithScene(context.Background(), "update")
+	if scene, ok := SceneFromContext(ctx); !ok || scene != "update" {
+		t.Fatalf("SceneFromContext = %q, %v", scene, ok)
+	}
+	if _, ok := SceneFromContext(WithScene(context.Background(), "")); ok {
+		t.Fatal("empty scene should not be reported")
+	}
+
+	assertErrors(t, v.BindingContext(ctx, &sceneUser{}), "id:required")
+
+	rules := map[string]interface{}{"id": "required@update", "name": "required@create"}
+	assertErrors(t, v.ValidateMapContext(ctx, map[string]interface{}{}, rules), "id:required")
+	assertErrors(t, v.ValidateMap(map[string]interface{}{}, rules))
+
+	assertMessage(t, v.VarContext(ctx, "", "required@update", "ID"), "ID为必填字段")
+	assertMessage(t, v.Var("", "required@update", "ID"), "")
+}
+
+type sceneJob struct {
+	Id int `json:"id" validate:"required@update" desc:"职位ID"`
+}
+
+type sceneForm struct {
+	Name string    `json:"name" validate:"required@create" desc:"姓名"`
+	Job  *sceneJob `json:"job" validate:"required@create"`
+}
+
+// 当前场景没有规则的嵌套字段仍验证其下级字段
+func TestSceneTraversal(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	res := v.BindingScene(&sceneForm{Job: &sceneJob{}}, "update")
+	assertErrors(t, res, "job.id:required")
+	assertMessage(t, res, "职位ID为必填字段")
+	assertErrors(t, v.BindingScene(&sceneForm{}, "update"))
+	assertErrors(t, v.BindingScene(&sceneForm{}, "create"), "name:required", "job:required")
+	assertErrors(t, v.Binding(&sceneForm{Job: &sceneJob{}}))
+}
@@ -0,0 +1,220 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// StructLevelFunc 结构体级别的验证方法,通过 sl.ReportError 报告字段错误
+type StructLevelFunc func(sl *StructLevel)
+
+// StructValidator 实现该接口的结构体在字段验证完成后自动调用 Validate
+// 返回 *FieldError 或 ValidationErrors 时按 StructPath(为空时使用 Field) 报告到对应的下级字段
+// eg: &validator.FieldError{StructPath: "Phone", Tag: "required"}
+// 设置了 Message 时直接使用该错误信息,否则按 Tag 翻译,Tag 为空时为 struct
+// 返回其他错误时报告到结构体本身,错误信息为 err.Error()
+type StructValidator interface {
+	Validate() error
+}
+
+var structValidatorType = reflect.TypeOf((*StructValidator)(nil)).Elem()
+
+// StructLevel 结构体级别验证时的结构体信息
+type StructLevel struct {
+	v          *validate
+	current    reflect.Value
+	path       string
+	structPath string
+	isHaveErr  bool
+}
+
+// RegisterStructValidation 注册结构体级别的验证方法,types 为结构体或其指针 eg: UserForm{}
+// 在结构体全部字段验证完成后调用
+func (v *Validator) RegisterStructValidation(fn StructLevelFunc, types ...interface{}) *Validator {
+	v.funcSLock.Lock()
+	if v.structFuncS == nil {
+		v.structFuncS = map[reflect.Type][]StructLevelFunc{}
+	}
+	for _, t := range types {
+		typ := reflect.TypeOf(t)
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		v.structFuncS[typ] = append(v.structFuncS[typ], fn)
+	}
+	v.funcSLock.Unlock()
+	// 已缓存的验证规则未遍历新注册验证方法的结构体字段
+	v.clearStructCache()
+	return v
+}
+
+// getStructValidations 获取结构体级别的验证方法
+func (v *Validator) getStructValidations(t reflect.Type) []StructLevelFunc {
+	v.funcSLock.RLock()
+	defer v.funcSLock.RUnlock()
+	return v.structFuncS[t]
+}
+
+// hasStructLevel 字段类型中是否有需要结构体级别验证的结构体,没有验证tag的字段也需要遍历
+func (v *Validator) hasStructLevel(t reflect.Type, visited map[reflect.Type]bool) bool {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+			continue
+		case reflect.Struct:
+		default:
+			return false
+		}
+		break
+	}
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+	if len(v.getStructValidations(t)) > 0 || reflect.PtrTo(t).Implements(structValidatorType) {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); sf.PkgPath == blank && v.hasStructLevel(sf.Type, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStructLevel 结构体字段验证完成后进行结构体级别验证
+// 返回是否有验证失败
+func (v *validate) validateStructLevel(current reflect.Value, path, structPath string) bool {
+	sl := &StructLevel{v: v, current: current, path: path, structPath: structPath}
+	for _, fn := range v.v.getStructValidations(current.Type()) {
+		fn(sl)
+		if sl.isHaveErr && !v.v.GetConfig().CollectAll {
+			return true
+		}
+	}
+	var sv StructValidator
+	if current.CanAddr() && current.Addr().Type().Implements(structValidatorType) {
+		sv = current.Addr().Interface().(StructValidator)
+	} else if current.Type().Implements(structValidatorType) && current.CanInterface() {
+		sv = current.Interface().(StructValidator)
+	}
+	if sv != nil {
+		if err := sv.Validate(); err != nil {
+			sl.reportErr(err)
+		}
+	}
+	return sl.isHaveErr
+}
+
+// reportErr 报告 Validate 方法返回的错误
+func (sl *StructLevel) reportErr(err error) {
+	var ve ValidationErrors
+	var fe *FieldError
+	switch {
+	case errors.As(err, &ve):
+	case errors.As(err, &fe):
+		ve = ValidationErrors{fe}
+	default:
+		sl.report(blank, structLevelTag, blank, err.Error())
+		return
+	}
+	// 设置了 Message 时直接使用,否则按 tag 翻译
+	for _, fe := range ve {
+		field := fe.StructPath
+		if field == blank {
+			field = fe.Field
+		}
+		tag := fe.Tag
+		if tag == blank {
+			tag = structLevelTag
+		}
+		sl.report(field, tag, fe.Param, fe.Message)
+	}
+}
+
+// Current 当前验证的结构体
+func (sl *StructLevel) Current() reflect.Value {
+	return sl.current
+}
+
+// Top 最外层结构体
+func (sl *StructLevel) Top() reflect.Value {
+	return sl.v.top
+}
+
+// ReportError 报告下级字段的验证错误,错误信息按 tag 翻译,与字段 tag 验证失败一致
+// field 为相对当前结构体的字段路径 eg: Phone, Addresses[1].Street,为空时报告到结构体本身
+// 自定义 tag 需通过 RegisterTranslation 注册错误信息模板
+func (sl *StructLevel) ReportError(field, tag string, param ...string) {
+	p := blank
+	if len(param) > 0 {
+		p = param[0]
+	}
+	sl.report(field, tag, p, blank)
+}
+
+// report 报告下级字段的验证错误,message 不为空时不再翻译错误信息
+func (sl *StructLevel) report(field, tag, param, message string) {
+	if sl.isHaveErr && !sl.v.v.GetConfig().CollectAll {
+		return
+	}
+	current, typ := sl.current, sl.current.Type()
+	path, structPath := sl.path, sl.structPath
+	var sf *reflect.StructField
+	for _, seg := range splitFormKey(field) {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		current, _ = extractTypeInternal(current)
+		switch typ.Kind() {
+		case reflect.Struct:
+			f, ok := typ.FieldByName(seg)
+			if !ok {
+				path, structPath, sf = joinPath(path, seg), joinPath(structPath, seg), nil
+				current, typ = reflect.Value{}, interfaceType
+				continue
+			}
+			sf = &f
+			path, structPath = joinPath(path, sl.v.fieldName(sf)), joinPath(structPath, f.Name)
+			if current.IsValid() {
+				current = current.FieldByIndex(f.Index)
+			}
+			typ = f.Type
+			continue
+		case reflect.Slice, reflect.Array:
+			if idx, err := strconv.Atoi(seg); err == nil && current.IsValid() && idx >= 0 && idx < current.Len() {
+				current = current.Index(idx)
+			} else {
+				current = reflect.Value{}
+			}
+		case reflect.Map:
+			if current.IsValid() && current.Type().Key().Kind() == reflect.String {
+				current = current.MapIndex(reflect.ValueOf(seg).Convert(current.Type().Key()))
+			} else {
+				current = reflect.Value{}
+			}
+		default:
+			current = reflect.Value{}
+		}
+		path, structPath = path+"["+seg+"]", structPath+"["+seg+"]"
+		if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+	}
+	if check, _ := sl.v.filter.match(structPath); !check {
+		return
+	}
+	f := &Field{
+		Path:       path,
+		StructPath: structPath,
+		Sf:         &reflect.StructField{Type: typ},
+		Tags:       &Tag{tag: tag, param: param, message: message, isHaveErr: true, rv: &current, parent: sl.current, top: sl.v.top},
+	}
+	if sf != nil {
+		f.Idx, f.Sf, f.JSONName, f.AliasName = sf.Index[len(sf.Index)-1], sf, sl.v.fieldName(sf), sl.v.structFieldAlias(sf)
+	}
+	sl.v.res.fields = append(sl.v.res.fields, f)
+	sl.isHaveErr = true
+}
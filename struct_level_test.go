@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type levelAddress struct {
+	Street string `json:"street" validate:"required" desc:"街道"`
+	City   string `json:"city" desc:"城市"`
+}
+
+type levelForm struct {
+	Name      string         `json:"name" validate:"required" desc:"姓名"`
+	Addresses []levelAddress `json:"addresses"`
+	Contact   levelContact   `json:"contact"`
+}
+
+type levelContact struct {
+	Phone string `json:"phone" desc:"手机号"`
+	Email string `json:"email" desc:"邮箱"`
+}
+
+func (c *levelContact) Validate() error {
+	switch {
+	case c.Phone == "" && c.Email == "":
+		return &FieldError{Field: "Phone", Tag: "required_without", Param: "Email"}
+	case c.Phone == "0":
+		return errors.New("手机号无效")
+	case c.Phone != "" && len(c.Phone) != 11:
+		return ValidationErrors{
+			{Field: "Phone", Tag: "len", Param: "11", Message: "手机号必须是11位"},
+			{StructPath: "Email", Message: "邮箱与手机号不能同时填写"},
+		}
+	}
+	return nil
+}
+
+func uniqueStreet(sl *StructLevel) {
+	form := sl.Current().Interface().(levelForm)
+	seen := map[string]bool{}
+	for i, addr := range form.Addresses {
+		if seen[addr.Street] {
+			sl.ReportError(fmt.Sprintf("Addresses[%d].Street", i), "unique")
+		}
+		seen[addr.Street] = true
+	}
+	if sl.Top().Interface().(levelForm).Name == "admin" {
+		sl.ReportError("Name", "ne", "admin")
+	}
+}
+
+func TestRegisterStructValidation(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	v.RegisterTranslation("unique", "{0}不能重复")
+	form := levelForm{
+		Name:      "admin",
+		Addresses: []levelAddress{{Street: "a"}, {Street: "b"}, {Street: "a"}},
+		Contact:   levelContact{Email: "a@b.c"},
+	}
+	// 未注册时没有验证 tag 的字段不验证
+	assertErrors(t, v.Binding(&form))
+
+	v.RegisterStructValidation(uniqueStreet, &levelForm{})
+	res := v.Binding(&form)
+	assertErrors(t, res, "addresses[2].street:unique", "name:ne")
+	assertMessage(t, res, "街道不能重复; 姓名不能等于admin")
+	if fe := res.Errors()[0]; fe.StructPath != "Addresses[2].Street" || fe.Value != "a" || fe.Field != "Street" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+	// 其他实例不受影响
+	assertErrors(t, New().Binding(&form))
+}
+
+func TestStructValidator(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	// 没有验证 tag 的字段实现 Validate 时同样被遍历
+	res := v.Binding(&levelForm{Name: "a"})
+	assertErrors(t, res, "contact.phone:required_without")
+	assertMessage(t, res, "手机号为必填字段")
+	if fe := res.Errors()[0]; fe.Code != 10023 || fe.Param != "Email" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+
+	res = v.Binding(&levelForm{Name: "a", Contact: levelContact{Phone: "0"}})
+	assertErrors(t, res, "contact:struct")
+	assertMessage(t, res, "手机号无效")
+	if fe := res.Errors()[0]; fe.Code != 10039 {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+	assertErrors(t, v.Binding(&levelForm{Contact: levelContact{Phone: "12345678901"}}), "name:required")
+
+	// 设置了 Message 时不再翻译,Tag 为空时为 struct
+	res = v.Binding(&levelForm{Name: "a", Contact: levelContact{Phone: "1"}})
+	assertErrors(t, res, "contact.phone:len", "contact.email:struct")
+	assertMessage(t, res, "手机号必须是11位; 邮箱与手机号不能同时填写")
+	if fe := res.Errors()[0]; fe.Code != 10002 || fe.Param != "11" || fe.Value != "1" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+}
@@ -0,0 +1,172 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// 内置翻译器语言标识
+const (
+	LocaleZh = "zh"
+	LocaleEn = "en"
+)
+
+// Translator 错误信息翻译器,不同语言的翻译器通过 Locale 区分
+type Translator interface {
+	// Locale 语言标识 eg: zh, en
+	Locale() string
+	// Translate 翻译单个验证失败字段的错误信息
+	Translate(field *Field) error
+	// AddTranslate 添加或覆盖错误信息模板,key 为 tag 或 tag-kind eg: max-string
+	AddTranslate(key, translate string)
+}
+
+// TranslateWords 翻译器使用的固定词语
+type TranslateWords struct {
+	Or        string //多个验证方法的错误信息连接词 eg: email|len=11
+	Undefined string //未定义错误信息模板
+	Invalid   string //错误信息模板无效
+	Subject   string //未设置字段别名时使用的主语 eg: Var 未传 alias
+}
+
+// MapTranslator 按错误信息模板翻译,不保存翻译结果,可在多个 goroutine 中共享使用
+// 其他语言只需提供错误信息模板及固定词语 eg: NewMapTranslator("ja", jaTemplates, jaWords)
+type MapTranslator struct {
+	locale       string
+	words        *TranslateWords
+	translateMap map[string]string
+	lock         sync.RWMutex
+}
+
+// NewMapTranslator 创建翻译器,templates 的 key 为 tag 或 tag-kind eg: required, max-string
+// {0} == 字段别名 ; {1} == tag 参数
+func NewMapTranslator(locale string, templates map[string]string, words *TranslateWords) *MapTranslator {
+	translateMap := make(map[string]string, len(templates))
+	for k, val := range templates {
+		translateMap[k] = val
+	}
+	return &MapTranslator{locale: locale, words: words, translateMap: translateMap}
+}
+
+func (m *MapTranslator) GetTranslateMap() map[string]string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.translateMap
+}
+
+func (m *MapTranslator) SetTranslateMap(translateMap map[string]string) *MapTranslator {
+	m.lock.Lock()
+	m.translateMap = translateMap
+	m.lock.Unlock()
+	return m
+}
+
+// AddTranslate 添加或覆盖错误信息模板,key 为 tag 或 tag-kind eg: max-string
+func (m *MapTranslator) AddTranslate(key, translate string) {
+	m.lock.Lock()
+	m.translateMap[key] = translate
+	m.lock.Unlock()
+}
+
+// Locale 语言标识
+func (m *MapTranslator) Locale() string {
+	return m.locale
+}
+
+// Translate 翻译单个验证失败字段的错误信息
+func (m *MapTranslator) Translate(field *Field) error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return translateField(m.translateMap, field, m.words)
+}
+
+// translateField 按错误信息模板翻译单个验证失败字段
+// {0} == field.AliasName
+// {1} == field.Tags.param
+func translateField(tMap map[string]string, field *Field, words *TranslateWords) error {
+	alias := field.AliasName
+	if alias == blank {
+		alias = words.Subject
+	}
+	//如果是指针类型则取指针对应真实类型
+	tKind := field.Sf.Type.Kind()
+	if tKind == reflect.Ptr {
+		tKind = field.Sf.Type.Elem().Kind()
+	}
+	//dive 验证元素时取元素的真实类型
+	if rv := field.Tags.rv; rv != nil {
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Invalid:
+		default:
+			tKind = rv.Kind()
+		}
+	}
+	// 多个验证方法全部失败时,依次列出每个验证方法的错误信息
+	if len(field.Tags.ors) > 1 {
+		msgs := make([]string, 0, len(field.Tags.ors))
+		for i, rule := range field.Tags.ors {
+			val, isOk := lookupTranslate(tMap, rule.tag, tKind)
+			if !isOk {
+				return errors.New(words.Undefined)
+			}
+			// 第一个之后的错误信息省略字段别名
+			if i > 0 {
+				val = strings.TrimSpace(strings.Replace(val, "{0}", "", 1))
+			}
+			msgs = append(msgs, strings.Replace(strings.Replace(val, "{0}", alias, 1), "{1}", rule.param, 1))
+		}
+		return errors.New(strings.Join(msgs, words.Or))
+	}
+	if val, isOk := lookupTranslate(tMap, field.Tags.tag, tKind); isOk {
+		param := field.Tags.param
+		if field.Tags.paramAlias != blank {
+			param = field.Tags.paramAlias
+		}
+		return formatErr(val, alias, param, words)
+	}
+	return errors.New(words.Undefined)
+}
+
+// lookupTranslate 获取错误信息模板
+// 判断tag是否有定义,再依次判断tag + kind 是否有定义 eg: max-int32 → max-int
+func lookupTranslate(tMap map[string]string, tag string, kind reflect.Kind) (string, bool) {
+	if val, isOk := tMap[tag]; isOk {
+		return val, true
+	}
+	for _, k := range translateKinds(kind) {
+		if val, isOk := tMap[tag+"-"+k]; isOk {
+			return val, true
+		}
+	}
+	return blank, false
+}
+
+// translateKinds 查找错误信息模板时依次使用的类型名称,未定义具体类型的模板时使用同类模板
+func translateKinds(kind reflect.Kind) []string {
+	switch kind {
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return []string{kind.String(), reflect.Int.String()}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return []string{kind.String(), reflect.Uint.String(), reflect.Int.String()}
+	case reflect.Float32:
+		return []string{kind.String(), reflect.Float64.String()}
+	case reflect.Array:
+		return []string{kind.String(), reflect.Slice.String()}
+	}
+	return []string{kind.String()}
+}
+
+func formatErr(translate, altName, tagParam string, words *TranslateWords) error {
+	if strings.ContainsAny(translate, "{0}&{1}") {
+		translate = strings.Replace(translate, "{0}", altName, 1)
+		translate = strings.Replace(translate, "{1}", tagParam, 1)
+		return errors.New(translate)
+	}
+	if strings.Contains(translate, "{0}") {
+		translate := strings.Replace(translate, "{0}", altName, 1)
+		return errors.New(translate)
+	}
+	return errors.New(words.Invalid)
+}
@@ -0,0 +1,192 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 每个内置验证方法都有中英文错误信息模板
+func TestTranslateMapsCoverBuiltins(t *testing.T) {
+	maps := map[string]map[string]string{LocaleZh: defaultTranslateMap, LocaleEn: defaultEnTranslateMap}
+	for locale, tMap := range maps {
+		for name := range validationFuncS {
+			found := false
+			for key := range tMap {
+				if key == name || strings.HasPrefix(key, name+"-") {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("%s translator has no template for %s", locale, name)
+			}
+		}
+	}
+	for key := range defaultTranslateMap {
+		if _, ok := defaultEnTranslateMap[key]; !ok {
+			t.Errorf("en translator has no template for %s", key)
+		}
+	}
+}
+
+func TestEnTranslator(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	en, ok := v.GetTranslatorByLocale(LocaleEn)
+	if !ok || en.Locale() != LocaleEn {
+		t.Fatal("en translator not registered")
+	}
+	v.SetTranslator(en)
+	res := v.Binding(&collectForm{Name: "abcdef", Age: 0, Email: "x", Addresses: []collectAddress{{}}})
+	assertMessage(t, res, "姓名 must be a maximum of 5 characters in length; 年龄 must be 1 or greater; 邮箱 must be a valid email address; 街道 is a required field")
+	assertMessage(t, v.Binding(&orForm{Contact: "abc"}), "联系方式 must be a valid email address or must be 11 characters in length")
+}
+
+func TestRegisterTranslationByLocale(t *testing.T) {
+	v := New()
+	if err := v.RegisterValidation("sku", func(tag *Tag) bool { return false }); err != nil {
+		t.Fatal(err)
+	}
+	v.RegisterTranslation("sku", "{0}必须以SKU开头").RegisterTranslation("sku", "{0} must start with SKU", LocaleEn)
+	form := &skuForm{Sku: "A1", Email: "a@b.cn"}
+	assertMessage(t, v.Binding(form), "商品编码必须以SKU开头")
+	en, _ := v.GetTranslatorByLocale(LocaleEn)
+	v.SetTranslator(en)
+	assertMessage(t, v.Binding(form), "商品编码 must start with SKU")
+	// 未注册的语言忽略
+	v.RegisterTranslation("sku", "x", "ja")
+	if _, ok := v.GetTranslatorByLocale("ja"); ok {
+		t.Fatal("unregistered locale created")
+	}
+}
+
+// upperTranslator 测试用翻译器,错误信息为大写的 tag
+type upperTranslator struct{}
+
+func (upperTranslator) Locale() string { return "up" }
+
+func (upperTranslator) Translate(field *Field) error {
+	return errors.New(strings.ToUpper(field.AliasName + " " + field.Tags.GetTag()))
+}
+
+func (upperTranslator) AddTranslate(key, translate string) {}
+
+func TestCustomTranslator(t *testing.T) {
+	v := New().RegisterTranslator(upperTranslator{})
+	if _, ok := v.GetTranslatorByLocale("up"); !ok {
+		t.Fatal("custom translator not registered")
+	}
+	assertMessage(t, v.Binding(&orForm{}), "联系方式为必填字段")
+	up, _ := v.GetTranslatorByLocale("up")
+	assertMessage(t, v.SetTranslator(up).Binding(&orForm{}), "联系方式 REQUIRED")
+}
+
+type aliasForm struct {
+	Phone   string `json:"phone" validate:"required" desc:"手机号" desc_en:"Phone"`
+	Email   string `json:"email" validate:"required" desc:"邮箱" desc_zh:"电子邮箱" desc_zh-TW:"電子郵箱"`
+	Nick    string `json:"nick" validate:"required"`
+	Address string `validate:"required" desc_en-GB:"Postal address" desc_en:"Address"`
+}
+
+func TestLocaleAlias(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	assertMessage(t, v.Binding(&aliasForm{}), "手机号为必填字段; 电子邮箱为必填字段; nick为必填字段; Address为必填字段")
+	res := v.BindingLocale(&aliasForm{}, "en")
+	assertMessage(t, res, "Phone is a required field; 邮箱 is a required field; nick is a required field; Address is a required field")
+	if fe := res.Errors()[0]; fe.Alias != "Phone" || fe.Field != "Phone" || fe.JSONName != "phone" {
+		t.Fatalf("FieldError = %+v", fe)
+	}
+	// 按请求的语言查找别名,zh-TW 使用 zh 翻译器时依次查找 desc_zh-TW → desc_zh
+	assertMessage(t, v.BindingLocale(&aliasForm{Phone: "1", Nick: "n", Address: "a"}, "zh-TW"), "電子郵箱为必填字段")
+	assertMessage(t, v.BindingLocale(&aliasForm{Phone: "1", Nick: "n", Address: "a"}, "zh-tw,en;q=0.5"), "電子郵箱为必填字段")
+	assertMessage(t, v.BindingLocale(&aliasForm{Phone: "1", Email: "a", Nick: "n"}, "en-GB,zh;q=0.5"), "Postal address is a required field")
+	// 未匹配任何翻译器时按默认翻译器的语言查找
+	assertMessage(t, v.BindingLocale(&aliasForm{Phone: "1", Nick: "n", Address: "a"}, "fr-FR"), "电子邮箱为必填字段")
+	// 注册 en-GB 翻译器时按 desc_en-GB → desc_en 查找
+	v.RegisterTranslator(NewMapTranslator("en-GB", defaultEnTranslateMap, enTranslateWords))
+	assertMessage(t, v.BindingLocale(&aliasForm{Phone: "1", Email: "a", Nick: "n"}, "en-GB"), "Postal address is a required field")
+}
+
+func TestTranslateKindFallback(t *testing.T) {
+	v := New()
+	tests := []struct {
+		field interface{}
+		tag   string
+		want  string
+	}{
+		{int8(5), "max=3", "值必须小于或等于3"},
+		{uint8(5), "max=3", "值必须小于或等于3"},
+		{float32(0.5), "gt=1", "值必须大于1"},
+		{[2]int{}, "len=3", "值必须包含3项"},
+		{[]int{1}, "min=2", "值至少包含2项"},
+	}
+	for _, tt := range tests {
+		assertMessage(t, v.Var(tt.field, tt.tag, "值"), tt.want)
+	}
+}
+
+func TestTranslateTime(t *testing.T) {
+	v := New()
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	tests := []struct {
+		field time.Time
+		tag   string
+		zh    string
+		en    string
+	}{
+		{future, "lt", "时间必须早于当前时间", "时间 must be before the current time"},
+		{future, "lte", "时间必须早于或等于当前时间", "时间 must be before or equal to the current time"},
+		{past, "gt", "时间必须晚于当前时间", "时间 must be after the current time"},
+		{past, "gte", "时间必须晚于或等于当前时间", "时间 must be after or equal to the current time"},
+	}
+	for _, tt := range tests {
+		assertMessage(t, v.Var(tt.field, tt.tag, "时间"), tt.zh)
+		assertMessage(t, v.VarContext(WithLocale(context.Background(), LocaleEn), tt.field, tt.tag, "时间"), tt.en)
+	}
+}
+
+func TestMapTranslator(t *testing.T) {
+	templates := map[string]string{
+		"required":   "{0}は必須です",
+		"email":      "{0}はメールアドレスではありません",
+		"len-string": "{0}は{1}文字です",
+	}
+	ja := NewMapTranslator("ja", templates, &TranslateWords{Or: " / ", Undefined: "不正なパラメータ", Invalid: "翻訳エラー"})
+	v := New().RegisterTranslator(ja)
+	v.GetConfig().CollectAll = true
+	assertMessage(t, v.BindingLocale(&orForm{}, "ja"), "联系方式は必須です")
+	assertMessage(t, v.BindingLocale(&orForm{Contact: "abc"}, "ja-JP"), "联系方式はメールアドレスではありません / は11文字です")
+	assertMessage(t, v.BindingLocale(&orForm{Contact: "a@b.c", Ids: []string{"1"}}, "ja"), "编号は2文字です / は4文字です")
+	// 没有对应模板时使用 Undefined
+	assertMessage(t, v.VarContext(WithLocale(context.Background(), "ja"), "abc", "max=1"), "不正なパラメータ")
+	// 模板在创建时复制,不影响传入的 map
+	ja.AddTranslate("required", "{0}がありません")
+	if templates["required"] != "{0}は必須です" {
+		t.Fatal("AddTranslate changed the templates passed to NewMapTranslator")
+	}
+	assertMessage(t, v.BindingLocale(&orForm{}, "ja"), "联系方式がありません")
+}
+
+// orTranslator 逐个翻译 | 分隔的每个验证方法
+type orTranslator struct{}
+
+func (orTranslator) Locale() string { return "or" }
+
+func (orTranslator) Translate(field *Field) error {
+	tags := make([]string, 0)
+	for _, tag := range field.Tags.GetOrs() {
+		tags = append(tags, tag.GetTag()+"("+tag.GetParam()+")")
+	}
+	return errors.New(field.AliasName + ": " + strings.Join(tags, ", "))
+}
+
+func (orTranslator) AddTranslate(key, translate string) {}
+
+func TestTagGetOrs(t *testing.T) {
+	v := New().RegisterTranslator(orTranslator{})
+	assertMessage(t, v.BindingLocale(&orForm{Contact: "abc"}, "or"), "联系方式: email(), len(11)")
+}
@@ -68,6 +68,62 @@ func asBool(param string) bool {
 	return i
 }
 
+// asInt 返回 tag 参数的 int64 值,优先使用预解析的参数
+func (t *Tag) asInt() int64 {
+	if t.p == nil {
+		return asInt(t.param)
+	}
+	panicIf(t.p.int64Err)
+	return t.p.int64Val
+}
+
+// asIntFromType 按字段类型返回 tag 参数的 int64 值,优先使用预解析的参数
+func (t *Tag) asIntFromType(typ reflect.Type) int64 {
+	if t.p == nil {
+		return asIntFromType(typ, t.param)
+	}
+	if typ == timeDurationType {
+		panicIf(t.p.durationErr)
+		return t.p.durationVal
+	}
+	return t.asInt()
+}
+
+// asUint 返回 tag 参数的 uint64 值,优先使用预解析的参数
+func (t *Tag) asUint() uint64 {
+	if t.p == nil {
+		return asUint(t.param)
+	}
+	panicIf(t.p.uint64Err)
+	return t.p.uint64Val
+}
+
+// asFloat 返回 tag 参数的 float64 值,优先使用预解析的参数
+func (t *Tag) asFloat() float64 {
+	if t.p == nil {
+		return asFloat(t.param)
+	}
+	panicIf(t.p.floatErr)
+	return t.p.floatVal
+}
+
+// asBool 返回 tag 参数的 bool 值,优先使用预解析的参数
+func (t *Tag) asBool() bool {
+	if t.p == nil {
+		return asBool(t.param)
+	}
+	panicIf(t.p.boolErr)
+	return t.p.boolVal
+}
+
+// oneOfVals 返回 oneof 参数列表,优先使用预解析的参数
+func (t *Tag) oneOfVals() []string {
+	if t.p == nil {
+		return parseOneOfParam2(t.param)
+	}
+	return t.p.oneOfVals
+}
+
 func panicIf(err error) {
 	if err != nil {
 		panic(err.Error())
@@ -1,32 +1,64 @@
 package validator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 func New() *Validator {
+	zh := NewZhTranslate()
 	return &Validator{
 		config: &Config{
 			FieldDescribeTag: defaultFieldDescribeTag,
 			ValidationTag:    defaultValidationTag,
 			OmitemptyTag:     defaultOmitemptyTag,
 		},
-		translate: NewZhTranslate(),
+		funcS:     map[string]Func{},
+		translate: zh,
+		translators: map[string]Translator{
+			LocaleZh: zh,
+			LocaleEn: NewEnTranslate(),
+		},
 	}
 }
 
+// Validator 配置完成后可在多个 goroutine 中共享使用
+// 每次验证的状态保存在各自的 Result 中,SetConfig 及注册方法应在使用前完成
 type Validator struct {
 	config    *Config
-	field     *Field
-	translate *ZhTranslate
-	err       error
+	funcS     map[string]Func //当前实例注册的验证方法
+	funcSLock sync.RWMutex
+	//当前实例注册的结构体级别验证方法 reflect.Type => []StructLevelFunc
+	structFuncS map[reflect.Type][]StructLevelFunc
+	translate   Translator //默认翻译器
+	//已注册的翻译器 locale => Translator
+	translators    map[string]Translator
+	translatorLock sync.RWMutex
+	codes          map[string]int //当前实例设置的错误码
+	codesLock      sync.RWMutex
+	//结构体验证规则缓存 cacheKey => *cStruct, ruleCacheKey => *cRules
+	structCache   sync.Map
+	ruleCacheSize int32 //已缓存的 map/Var 验证规则数量
 }
 
-func (v *Validator) GetField() *Field {
-	return v.field
+// validate 单次验证过程中的状态
+type validate struct {
+	v          *Validator
+	res        *Result
+	top        reflect.Value   //最外层结构体,跨字段验证时使用
+	translator Translator      //本次验证使用的翻译器
+	scene      string          //本次验证的场景
+	filter     *fieldFilter    //StructPartial/StructExcept 字段筛选条件
+	presence   presenceSet     //BindingJSON 时 json 中存在的字段路径
+	parents    []reflect.Value //正在验证的各级结构体,由外到内,跨字段验证时逐级查找
+	paths      []string        //parents 对应的 json 路径
+	skipPaths  []string        //BindingJSON 开启 CollectAll 时 json 类型错误的字段路径,不再验证
+	locale     string          //协商的错误信息语言,用于查找 desc_<locale> 别名
+	form       bool            //BindRequest 解析表单、query 参数时字段路径及别名使用 form 标签名
 }
 
 func (v *Validator) SetConfig(conf *Config) *Validator {
@@ -38,172 +70,433 @@ func (v *Validator) GetConfig() *Config {
 	return v.config
 }
 
-func (v *Validator) Error() error {
-	return v.err
+// RegisterValidation 注册当前实例的验证方法,不影响其他 Validator 实例
+// 与内置验证方法重名时返回错误,override 为 true 时覆盖内置验证方法
+func (v *Validator) RegisterValidation(name string, fn Func, override ...bool) error {
+	if name == blank {
+		return errors.New(emptyFuncName)
+	}
+	if strings.ContainsAny(name, tagSeparator+orSeparator+tagKeySeparator+sceneSeparator) || name == skipValidationTag || name == v.GetConfig().OmitemptyTag ||
+		name == diveTag || name == keysTag || name == endKeysTag {
+		return fmt.Errorf(invalidFuncName, name)
+	}
+	if fn == nil {
+		return fmt.Errorf(nilValidationFunc, name)
+	}
+	if _, ok := validationFuncS[name]; ok && (len(override) == 0 || !override[0]) {
+		return fmt.Errorf(builtinValidation, name)
+	}
+	v.funcSLock.Lock()
+	v.funcS[name] = fn
+	v.funcSLock.Unlock()
+	// 已缓存的验证规则可能引用了旧的验证方法
+	v.clearStructCache()
+	return nil
+}
+
+// RegisterTranslation 注册当前实例验证方法的错误信息模板
+// {0} == 字段别名 ; {1} == tag 参数 ; eg: "{0}必须是有效的SKU"
+// 未指定 locale 时注册到默认翻译器
+func (v *Validator) RegisterTranslation(name, translate string, locale ...string) *Validator {
+	if len(locale) == 0 {
+		v.GetTranslator().AddTranslate(name, translate)
+		return v
+	}
+	for _, l := range locale {
+		if t, ok := v.GetTranslatorByLocale(l); ok {
+			t.AddTranslate(name, translate)
+		}
+	}
+	return v
+}
+
+// SetTranslator 设置默认翻译器,同时注册为对应 locale 的翻译器
+func (v *Validator) SetTranslator(t Translator) *Validator {
+	v.translatorLock.Lock()
+	v.translate = t
+	v.translators[strings.ToLower(t.Locale())] = t
+	v.translatorLock.Unlock()
+	return v
 }
 
-func (v *Validator) SetError(err error) *Validator {
-	v.err = err
+// GetTranslator 默认翻译器
+func (v *Validator) GetTranslator() Translator {
+	v.translatorLock.RLock()
+	defer v.translatorLock.RUnlock()
+	return v.translate
+}
+
+// RegisterTranslator 注册其他语言的翻译器,相同 locale 时覆盖
+func (v *Validator) RegisterTranslator(t Translator) *Validator {
+	v.translatorLock.Lock()
+	v.translators[strings.ToLower(t.Locale())] = t
+	v.translatorLock.Unlock()
 	return v
 }
 
+// GetTranslatorByLocale 获取指定 locale 的翻译器,不区分大小写
+func (v *Validator) GetTranslatorByLocale(locale string) (Translator, bool) {
+	v.translatorLock.RLock()
+	defer v.translatorLock.RUnlock()
+	t, ok := v.translators[strings.ToLower(locale)]
+	return t, ok
+}
+
+// 获取验证方法,优先使用当前实例注册的验证方法
+func (v *Validator) getValidationFunc(name string) (Func, bool) {
+	v.funcSLock.RLock()
+	fn, ok := v.funcS[name]
+	v.funcSLock.RUnlock()
+	if ok {
+		return fn, true
+	}
+	fn, ok = validationFuncS[name]
+	return fn, ok
+}
+
 /**
 验证完数据后，返回接收对象
 1、给reqValidate赋值
 2、解析reqValidate每一个字段信息
 */
-func (v *Validator) Binding(obj interface{}) *Validator {
+func (v *Validator) Binding(obj interface{}) *Result {
+	return v.BindingContext(context.Background(), obj)
+}
+
+// BindingContext 同 Binding,错误信息使用 ctx 中 WithLocale 设置的语言
+func (v *Validator) BindingContext(ctx context.Context, obj interface{}) *Result {
+	return v.newValidate(ctx).binding(obj)
+}
+
+// BindingLocale 同 Binding,错误信息按 Accept-Language 选择语言 eg: zh-TW,zh;q=0.9,en;q=0.8
+func (v *Validator) BindingLocale(obj interface{}, acceptLanguage string) *Result {
+	return v.BindingContext(WithLocale(context.Background(), acceptLanguage), obj)
+}
+
+// newValidate 创建单次验证的状态
+func (v *Validator) newValidate(ctx context.Context) *validate {
+	vd := &validate{v: v, res: &Result{}, translator: v.GetTranslator()}
+	vd.locale = vd.translator.Locale()
+	if locale, ok := LocaleFromContext(ctx); ok {
+		vd.translator, vd.locale = v.negotiateLocale(locale)
+	}
+	vd.scene, _ = SceneFromContext(ctx)
+	return vd
+}
+
+// binding 验证结构体
+func (v *validate) binding(obj interface{}) *Result {
 	value := reflect.ValueOf(obj)
 	//确保 obj 是struct
 	if value.Kind() == reflect.Ptr && !value.IsNil() {
 		value = value.Elem()
 	}
 	if value.Kind() != reflect.Struct && value.Kind() != reflect.Interface {
-		v.SetError(errors.New(mustStruct))
-		return v
+		return v.res.SetError(errors.New(mustStruct))
 	}
+	v.top = value
 	// 遍历 Struct 字段结构 & 校验数据
-	isValidationFuncErr := v.extractStruct(value)
+	v.extractStruct(value, blank, blank)
 	// 解析参数校验错误信息
-	if isValidationFuncErr {
-		v.SetError(v.translate.Translate(v).GetErr())
+	return v.translateFields()
+}
+
+// translateFields 翻译全部验证失败字段的错误信息
+func (v *validate) translateFields() *Result {
+	res := v.res
+	if len(res.fields) == 0 {
+		return res
 	}
-	return v
+	res.field = res.fields[0]
+	for _, field := range res.fields {
+		field.AliasName = v.localeAlias(field)
+		if sf := field.Tags.paramSf; sf != nil {
+			field.Tags.paramAlias = v.structFieldAlias(sf)
+		}
+		var err error
+		if field.Tags.message != blank {
+			err = errors.New(field.Tags.message)
+		} else {
+			err = v.translator.Translate(field)
+		}
+		fe := newFieldError(field, err)
+		fe.Code, fe.OrCodes = v.v.errorCode(field.Tags), v.v.orErrorCodes(field.Tags)
+		res.errs = append(res.errs, fe)
+	}
+	return res.SetError(res.errs)
+}
+
+// localeAlias 获取当前翻译器语言对应的字段别名 eg: desc_en:"Phone"
+// 按 desc_zh-TW → desc_zh 依次查找,未设置时使用 desc 别名或 json 标签名
+func (v *validate) localeAlias(field *Field) string {
+	if alias, ok := v.localeDesc(field.Sf); ok {
+		return alias
+	}
+	return field.AliasName
+}
+
+// structFieldAlias 字段在当前翻译器语言下的别名,依次使用 desc_<locale>、desc、字段名称
+func (v *validate) structFieldAlias(sf *reflect.StructField) string {
+	if alias, ok := v.localeDesc(sf); ok {
+		return alias
+	}
+	if desc := sf.Tag.Get(v.v.GetConfig().FieldDescribeTag); desc != blank {
+		return desc
+	}
+	return v.fieldName(sf)
+}
+
+// fieldName 字段在路径中的名称,表单请求时为 form 标签名,否则为 json 标签名
+func (v *validate) fieldName(sf *reflect.StructField) string {
+	if v.form {
+		return formName(sf)
+	}
+	return jsonName(sf)
+}
+
+// localeDesc 按协商的语言依次查找别名 eg: zh-TW 使用 zh 翻译器时依次查找 desc_zh-TW → desc_zh
+func (v *validate) localeDesc(sf *reflect.StructField) (string, bool) {
+	prefix := v.v.GetConfig().FieldDescribeTag + "_"
+	locale := v.locale
+	for locale != blank {
+		if alias := sf.Tag.Get(prefix + canonicalLocale(locale)); alias != blank {
+			return alias, true
+		}
+		if alias := sf.Tag.Get(prefix + strings.ToLower(locale)); alias != blank {
+			return alias, true
+		}
+		idx := strings.LastIndex(locale, "-")
+		if idx <= 0 {
+			break
+		}
+		locale = locale[:idx]
+	}
+	return blank, false
 }
 
 // 提取 Struct 字段信息
-func (v *Validator) extractStruct(current reflect.Value) bool {
-	// 结构体信息
-	currentType := current.Type()
-	// 获取结构体字段数量
-	numFields := currentType.NumField()
-	for i := 0; i < numFields; i++ {
-		currentField := current.Field(i)
-		// 获取每个字段信息
-		currentStructField := currentType.Field(i)
-		// 获取字段名
-		fieldName := currentStructField.Name
-		// 是否空字段"-", struct{-}
-		if !currentStructField.Anonymous && currentStructField.PkgPath != blank {
-			continue
+// 返回是否有字段验证失败,未开启 CollectAll 时遇到第一个错误立即返回
+// path 为 json 路径, structPath 为结构体字段路径
+func (v *validate) extractStruct(current reflect.Value, path, structPath string) bool {
+	v.parents, v.paths = append(v.parents, current), append(v.paths, path)
+	defer func() { v.parents, v.paths = v.parents[:len(v.parents)-1], v.paths[:len(v.paths)-1] }()
+	isHaveErr := false
+	// 获取预编译的结构体验证规则
+	cs := v.v.extractStructCache(current.Type(), v.scene)
+	for _, cf := range cs.fields {
+		currentField := current.Field(cf.idx)
+		fieldPath := joinPath(path, cf.jsonName)
+		if v.form {
+			fieldPath = joinPath(path, cf.formName)
 		}
-		// 获取验证标签
-		validateTag := currentStructField.Tag.Get(v.GetConfig().ValidationTag)
-		// 验证标签是否忽略或者为空
-		if validateTag == skipValidationTag || validateTag == blank {
+		// 匿名嵌入且未设置json标签时,json路径与上级一致
+		if cf.inline {
+			fieldPath = path
+		}
+		fieldStructPath := joinPath(structPath, cf.name)
+		check, traverse := v.filter.match(fieldStructPath)
+		if !traverse || cf.partial && !v.filter.named(fieldStructPath) {
 			continue
 		}
-		// 如果有验证Tag,则进行数据验证
-		if len(validateTag) > 0 {
-			tags := v.parseFieldTags(current.Field(i), validateTag, currentStructField.Name)
-			if tags != nil && tags.isHaveErr == true {
-				//如果设置字段别名
-				descTag := currentStructField.Tag.Get(v.GetConfig().FieldDescribeTag)
-				v.field = &Field{Idx: i, AliasName: fieldName, Sf: &currentStructField, Tags: tags}
-				if descTag != blank {
-					v.field.AliasName = descTag
-				}
+		// 进行数据验证
+		switch {
+		case !check:
+			// 指定字段的上级字段,只遍历下级字段
+		case cf.err != nil:
+			v.res.SetError(cf.err)
+		case v.validateRules(currentField, current, cf, cf.rules, fieldPath, fieldStructPath):
+			if !v.v.GetConfig().CollectAll {
 				return true
 			}
+			isHaveErr = true
 		}
 		// 递归处理,深层级逻辑
-		if v.handleCurrentField(currentField) {
-			return true
+		if v.handleCurrentField(currentField, fieldPath, fieldStructPath) {
+			if !v.v.GetConfig().CollectAll {
+				return true
+			}
+			isHaveErr = true
 		}
 	}
-	return false
+	// 字段验证通过或开启 CollectAll 时进行结构体级别验证
+	if v.validateStructLevel(current, path, structPath) {
+		isHaveErr = true
+	}
+	return isHaveErr
 }
 
 // 递归处理,深层级逻辑
-func (v *Validator) handleCurrentField(current reflect.Value) bool {
-	switch current.Type().Kind() {
+func (v *validate) handleCurrentField(current reflect.Value, path, structPath string) bool {
+	// nil 指针或接口,没有下级字段
+	if !current.IsValid() || v.skipped(path) {
+		return false
+	}
+	switch current.Kind() {
 	case reflect.Ptr, reflect.Interface:
-		if v.handleCurrentField(current.Elem()) {
-			return true
-		}
-	case reflect.Struct, reflect.Map:
-		if v.extractStruct(current) {
-			return true
-		}
+		return v.handleCurrentField(current.Elem(), path, structPath)
+	case reflect.Struct:
+		return v.extractStruct(current, path, structPath)
 	case reflect.Slice, reflect.Array:
+		// 如果Slice是值类型时不校验,eg:[1],["a"]
+		if !hasNestedField(current.Type().Elem()) {
+			return false
+		}
+		isHaveErr := false
 		for j := 0; j < current.Len(); j++ {
-			// 如果Slice是值类型时不校验,eg:[1],["a"]
-			switch current.Index(j).Kind() {
-			case reflect.String, reflect.Int, reflect.Int64:
-				return false
-			case reflect.Ptr, reflect.Interface:
-				if v.handleCurrentField(current.Index(j).Elem()) {
+			if v.handleCurrentField(current.Index(j), indexPath(path, j), indexPath(structPath, j)) {
+				if !v.v.GetConfig().CollectAll {
 					return true
 				}
-				return false
+				isHaveErr = true
 			}
-			if v.extractStruct(current.Index(j)) {
-				return true
+		}
+		return isHaveErr
+	case reflect.Map:
+		if !hasNestedField(current.Type().Elem()) {
+			return false
+		}
+		isHaveErr := false
+		for _, key := range sortedMapKeys(current) {
+			if v.handleCurrentField(current.MapIndex(key), keyPath(path, key), keyPath(structPath, key)) {
+				if !v.v.GetConfig().CollectAll {
+					return true
+				}
+				isHaveErr = true
 			}
 		}
+		return isHaveErr
 	}
 	return false
 }
 
+// validateRules 按验证规则验证字段值,有 dive 时继续验证每个元素
+// 返回是否有验证失败,未开启 CollectAll 时遇到第一个错误立即返回
+func (v *validate) validateRules(current, parent reflect.Value, cf *cField, rules *cRules, path, structPath string) bool {
+	if v.skipped(path) {
+		return false
+	}
+	tags := v.parseFieldTags(current, parent, rules.tags, path)
+	if tags != nil && tags.isHaveErr == true {
+		// 验证结束后上级结构体列表会继续变化,保存当前的副本
+		tags.ancestors, tags.paths = append([]reflect.Value(nil), tags.ancestors...), append([]string(nil), tags.paths...)
+		if crossFieldFuncS[tags.tag] {
+			_, tags.paramSf, _, _ = tags.lookup(tags.param)
+		}
+		field := &Field{
+			Idx:        cf.idx,
+			AliasName:  cf.alias,
+			JSONName:   cf.jsonName,
+			Path:       path,
+			StructPath: structPath,
+			Sf:         &cf.sf,
+			Tags:       tags,
+		}
+		if v.form {
+			field.AliasName, field.JSONName = cf.formAlias, cf.formName
+		}
+		v.res.fields = append(v.res.fields, field)
+		return true
+	}
+	if rules.dive == nil {
+		return false
+	}
+	isHaveErr := false
+	current, kind := extractTypeInternal(current)
+	switch kind {
+	case reflect.Ptr, reflect.Interface, reflect.Invalid:
+		// nil 指针或接口,没有元素
+		return false
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < current.Len(); i++ {
+			if v.validateRules(current.Index(i), parent, cf, rules.dive, indexPath(path, i), indexPath(structPath, i)) {
+				if !v.v.GetConfig().CollectAll {
+					return true
+				}
+				isHaveErr = true
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(current) {
+			elemPath, elemStructPath := keyPath(path, key), keyPath(structPath, key)
+			if rules.keys != nil && v.validateRules(key, parent, cf, rules.keys, elemPath, elemStructPath) {
+				if !v.v.GetConfig().CollectAll {
+					return true
+				}
+				isHaveErr = true
+			}
+			if v.validateRules(current.MapIndex(key), parent, cf, rules.dive, elemPath, elemStructPath) {
+				if !v.v.GetConfig().CollectAll {
+					return true
+				}
+				isHaveErr = true
+			}
+		}
+	default:
+		v.res.SetError(fmt.Errorf(invalidDive, cf.name))
+	}
+	return isHaveErr
+}
+
 // 验证数据
-func (v *Validator) parseFieldTags(current reflect.Value, tagStr string, fieldName string) *Tag {
-	var validaTag string
-	var kind reflect.Kind
-	var tags []string
+// parent 为字段所在的结构体,跨字段验证时使用
+func (v *validate) parseFieldTags(current, parent reflect.Value, cTags []*cTag, path string) *Tag {
 	var tag Tag
-	var vals []string
+	// 字段为空且可跳过时,只进行条件验证 eg: omitempty,required_if=Type 1
+	var skip bool
 	// 获取真实数据类型
-	current, kind = v.extractTypeInternal(current)
-	// 获取验证Tag列表
-	tags = strings.Split(tagStr, tagSeparator)
-	for i := 0; i < len(tags); i++ {
-		validaTag = tags[i]
+	current, kind := extractTypeInternal(current)
+	// 字段不存在(eg: map 缺少key)或为 nil 指针、接口(eg: dive 时切片中的 null)
+	isNil := kind == reflect.Invalid || kind == reflect.Ptr || kind == reflect.Interface
+	isEmpty := v.isEmpty(current, path)
+	if v.presence != nil {
+		present := !isEmpty
+		tag.present = &present
+	}
+	for _, ct := range cTags {
 		// 当Tag == OmitemptyTag 时，再验证
-		if v.GetConfig().OmitemptyTag != "" && validaTag == v.GetConfig().OmitemptyTag {
-			switch kind {
-			case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
-				if !current.IsNil() {
-					continue
-				}
-			default:
-				if current.IsValid() && current.Interface() != reflect.Zero(current.Type()).Interface() {
-					continue
-				}
+		if ct.isOmitempty {
+			if isEmpty {
+				skip = true
 			}
-			return nil
-		}
-		tag.rv = &current
-		orVials := strings.Split(validaTag, orSeparator)
-		for j := 0; j < len(orVials); j++ {
-			// 获取验证值
-			vals = strings.SplitN(orVials[j], tagKeySeparator, 2)
-			tag.tag = vals[0]
-			if len(tag.tag) == 0 {
-				v.SetError(errors.New(strings.TrimSpace(fmt.Sprintf(invalidValidation, fieldName))))
-				return nil
+			continue
+		}
+		if skip && !ct.isConditional {
+			continue
+		}
+		tag.rv, tag.parent, tag.top, tag.ancestors = &current, parent, v.top, v.parents
+		tag.presence, tag.paths = v.presence, v.paths
+		passed := false
+		for _, rule := range ct.ors {
+			tag.tag, tag.param, tag.p = rule.tag, rule.param, rule.p
+			// 字段不存在或为 nil 时,只进行必填类验证
+			if isNil && rule.tag != requiredTag && !ct.isConditional {
+				passed = true
+				break
 			}
-			if len(vals) > 1 {
-				tag.param = strings.Replace(strings.Replace(vals[1], utf8HexComma, ",", -1), utf8Pipe, "|", -1)
+			// 验证,多个验证方法时任一通过即可
+			if rule.fn(&tag) {
+				passed = true
+				break
 			}
-			// 验证
-			if validationFunc, ok := validationFuncS[tag.tag]; !ok {
-				v.SetError(errors.New(strings.TrimSpace(fmt.Sprintf(undefinedValidation, fieldName))))
-				return nil
-			} else {
-				validationFuncResult := validationFunc(&tag)
-				// 验证
-				if !validationFuncResult {
-					tag.isHaveErr = true
-					return &tag
-				}
+		}
+		if !passed {
+			tag.isHaveErr = true
+			// 多个验证方法全部失败时,记录完整tag及全部验证方法 eg: email|len=11
+			if len(ct.ors) > 1 {
+				tag.tag, tag.param, tag.p, tag.ors = ct.raw, blank, nil, ct.ors
 			}
+			return &tag
+		}
+		// 条件必填且条件不满足时,字段为空则跳过后续验证
+		if ct.skipWhenEmpty && isEmpty {
+			skip = true
 		}
 	}
 	return nil
 }
 
 // 获取真实数据类型
-func (v *Validator) extractTypeInternal(current reflect.Value) (reflect.Value, reflect.Kind) {
+func extractTypeInternal(current reflect.Value) (reflect.Value, reflect.Kind) {
 
 BEGIN:
 	switch current.Kind() {
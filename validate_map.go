@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// ruleCacheKey map 验证规则缓存key
+type ruleCacheKey struct {
+	rule         string
+	omitemptyTag string
+	scene        string
+}
+
+// ValidateMap 按规则验证 map 数据,规则语法与 validate tag 一致
+// 规则值为 string 时验证对应 key 的值
+// 规则值为 map[string]interface{} 时验证嵌套对象,数据为数组时验证每个元素
+// eg:
+//
+//	rules := map[string]interface{}{
+//		"name":      "required,max=10",
+//		"job":       map[string]interface{}{"id": "required,min=1"},
+//		"addresses": map[string]interface{}{"street": "required"},
+//	}
+func (v *Validator) ValidateMap(data map[string]interface{}, rules map[string]interface{}) *Result {
+	return v.ValidateMapContext(context.Background(), data, rules)
+}
+
+// ValidateMapContext 同 ValidateMap,错误信息使用 ctx 中 WithLocale 设置的语言
+func (v *Validator) ValidateMapContext(ctx context.Context, data map[string]interface{}, rules map[string]interface{}) *Result {
+	vd := v.newValidate(ctx)
+	vd.top = reflect.ValueOf(data)
+	vd.extractMap(vd.top, rules, blank)
+	return vd.translateFields()
+}
+
+// extractRuleCache 获取 map 验证规则,未缓存时解析并缓存,不同场景分别缓存
+// 缓存数量达到 maxRuleCacheSize 后新规则每次解析不再缓存
+func (v *Validator) extractRuleCache(key, rule, scene string) (*cRules, error) {
+	conf := v.GetConfig()
+	cacheKey := ruleCacheKey{rule: rule, omitemptyTag: conf.OmitemptyTag, scene: scene}
+	if rules, ok := v.structCache.Load(cacheKey); ok {
+		return rules.(*cRules), nil
+	}
+	rules, err := v.parseTags(filterSceneTags(strings.Split(rule, tagSeparator), scene), key, conf)
+	if err != nil {
+		return nil, err
+	}
+	if atomic.LoadInt32(&v.ruleCacheSize) >= maxRuleCacheSize {
+		return rules, nil
+	}
+	if _, loaded := v.structCache.LoadOrStore(cacheKey, rules); !loaded {
+		atomic.AddInt32(&v.ruleCacheSize, 1)
+	}
+	return rules, nil
+}
+
+// 提取 map 字段信息
+// 返回是否有字段验证失败,未开启 CollectAll 时遇到第一个错误立即返回
+func (v *validate) extractMap(current reflect.Value, rules map[string]interface{}, path string) bool {
+	isHaveErr := false
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		field := current.MapIndex(reflect.ValueOf(key).Convert(current.Type().Key()))
+		fieldPath := joinPath(path, key)
+		var isFieldErr bool
+		switch rule := rules[key].(type) {
+		case string:
+			rs, err := v.v.extractRuleCache(key, rule, v.scene)
+			if err != nil {
+				v.res.SetError(err)
+				continue
+			}
+			cf := &cField{
+				name:     key,
+				jsonName: key,
+				alias:    key,
+				sf:       reflect.StructField{Name: key, Type: current.Type().Elem()},
+			}
+			isFieldErr = v.validateRules(field, current, cf, rs, fieldPath, fieldPath)
+		case map[string]interface{}:
+			isFieldErr = v.handleMapField(field, rule, key, fieldPath)
+		default:
+			v.res.SetError(fmt.Errorf(invalidMapRule, fieldPath))
+		}
+		if isFieldErr {
+			if !v.v.GetConfig().CollectAll {
+				return true
+			}
+			isHaveErr = true
+		}
+	}
+	return isHaveErr
+}
+
+// 递归处理嵌套对象及数组
+// 值不存在或为 null 时按空对象验证,只进行必填类验证;值不是对象或数组时返回 type_object 错误
+func (v *validate) handleMapField(current reflect.Value, rules map[string]interface{}, name, path string) bool {
+	current, kind := extractTypeInternal(current)
+	switch kind {
+	case reflect.Invalid, reflect.Ptr, reflect.Interface:
+		return v.extractMap(reflect.ValueOf(map[string]interface{}{}), rules, path)
+	case reflect.Map:
+		if current.Type().Key().Kind() == reflect.String {
+			return v.extractMap(current, rules, path)
+		}
+	case reflect.Slice, reflect.Array:
+		isHaveErr := false
+		for i := 0; i < current.Len(); i++ {
+			if v.handleMapField(current.Index(i), rules, name, indexPath(path, i)) {
+				if !v.v.GetConfig().CollectAll {
+					return true
+				}
+				isHaveErr = true
+			}
+		}
+		return isHaveErr
+	}
+	v.res.fields = append(v.res.fields, &Field{
+		AliasName:  name,
+		JSONName:   name,
+		Path:       path,
+		StructPath: path,
+		Sf:         &reflect.StructField{Name: name, Type: current.Type()},
+		Tags:       &Tag{tag: typeTagPrefix + "object", param: jsonKind(current.Type()), isHaveErr: true, rv: &current},
+	})
+	return true
+}
@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func decodeMap(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+var mapRules = map[string]interface{}{
+	"name":      "required,max=5",
+	"age":       "omitempty,gte=18",
+	"tags":      "omitempty,min=1,dive,required",
+	"job":       map[string]interface{}{"id": "required,min=1", "title": "max=3"},
+	"addresses": map[string]interface{}{"street": "required"},
+}
+
+func TestValidateMap(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	assertErrors(t, v.ValidateMap(decodeMap(t, `{"name":"a","job":{"id":1},"addresses":[{"street":"s"}]}`), mapRules))
+
+	res := v.ValidateMap(decodeMap(t, `{"name":"abcdef","age":17,"tags":["a",""],"job":{"id":-1,"title":"abcd"},"addresses":[{"street":"s"},{}]}`), mapRules)
+	assertErrors(t, res, "addresses[1].street:required", "age:gte", "job.id:min", "job.title:max", "name:max", "tags[1]:required")
+	assertMessage(t, res, "street为必填字段; age必须大于或等于18; id最小只能为1; title长度不超过3个字符; name长度不超过5个字符; tags为必填字段")
+	if fe := res.Errors()[2]; fe.Value != float64(-1) || fe.Param != "1" || fe.Field != "id" {
+		t.Fatalf("FieldError = %+v", fe)
+	}
+
+	// 缺少的 key 只进行必填类验证
+	res = v.ValidateMap(map[string]interface{}{"job": map[string]interface{}{"id": 2}}, mapRules)
+	assertErrors(t, res, "addresses.street:required", "name:required")
+
+	en := WithLocale(context.Background(), LocaleEn)
+	assertMessage(t, v.ValidateMapContext(en, map[string]interface{}{}, map[string]interface{}{"name": "required"}), "name is a required field")
+}
+
+func TestValidateMapInvalidRule(t *testing.T) {
+	v := New()
+	assertMessage(t, v.ValidateMap(map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1}), "Invalid rule type on key a, must be string or map[string]interface{}")
+	assertMessage(t, v.ValidateMap(map[string]interface{}{"a": 1}, map[string]interface{}{"a": "unknown"}), "Undefined validation function on field a")
+}
+
+func TestValidateMapNested(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	rules := map[string]interface{}{"job": map[string]interface{}{"id": "required", "title": "max=3"}}
+	// 缺少或为 null 时按空对象验证
+	assertErrors(t, v.ValidateMap(map[string]interface{}{}, rules), "job.id:required")
+	assertErrors(t, v.ValidateMap(map[string]interface{}{"job": nil}, rules), "job.id:required")
+
+	res := v.ValidateMap(decodeMap(t, `{"job":"x"}`), rules)
+	assertErrors(t, res, "job:type_object")
+	assertMessage(t, res, "job必须是对象")
+	if fe := res.Errors()[0]; fe.Param != "string" || fe.Value != "x" {
+		t.Fatalf("FieldError = %#v", fe)
+	}
+	assertErrors(t, v.ValidateMap(decodeMap(t, `{"job":[{"id":1},2,{}]}`), rules), "job[1]:type_object", "job[2].id:required")
+}
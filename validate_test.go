@@ -0,0 +1,419 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fieldErrors 验证失败字段的 path:tag 列表
+func fieldErrors(res *Result) []string {
+	errs := make([]string, 0, len(res.Errors()))
+	for _, fe := range res.Errors() {
+		errs = append(errs, fe.Path+":"+fe.Tag)
+	}
+	return errs
+}
+
+// assertErrors 比较验证失败字段的 path:tag 列表,want 为空时验证通过
+func assertErrors(t *testing.T, res *Result, want ...string) {
+	t.Helper()
+	if got := fieldErrors(res); len(got) != len(want) || len(want) > 0 && !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %v, want %v ; %v", got, want, res.Error())
+	}
+}
+
+// assertMessage 比较验证结果的错误信息,want 为空时验证通过
+func assertMessage(t *testing.T, res *Result, want string) {
+	t.Helper()
+	if got := fmt.Sprint(res.Error()); want == "" && res.Error() != nil || want != "" && got != want {
+		t.Fatalf("error = %s, want %s", got, want)
+	}
+}
+
+type collectAddress struct {
+	Street string `json:"street" validate:"required" desc:"街道"`
+}
+
+type collectForm struct {
+	Name      string           `json:"name" validate:"required,max=5" desc:"姓名"`
+	Age       int              `json:"age" validate:"gte=1,lte=100" desc:"年龄"`
+	Email     string           `json:"email" validate:"required,email" desc:"邮箱"`
+	Addresses []collectAddress `json:"addresses" validate:"required"`
+}
+
+func TestCollectAll(t *testing.T) {
+	form := &collectForm{Name: "abcdef", Age: 101, Addresses: []collectAddress{{Street: "a"}, {}}}
+	v := New()
+	res := v.Binding(form)
+	if err := res.Error(); err == nil || err.Error() != "姓名长度不超过5个字符" {
+		t.Fatalf("first error = %v", err)
+	}
+	if n := len(res.GetFields()); n != 1 {
+		t.Fatalf("fields = %d, want 1", n)
+	}
+
+	v.GetConfig().CollectAll = true
+	res = v.Binding(form)
+	err := res.Error()
+	want := "姓名长度不超过5个字符; 年龄必须小于或等于100; 邮箱为必填字段; 街道为必填字段"
+	if err == nil || err.Error() != want {
+		t.Fatalf("error = %v, want %s", err, want)
+	}
+	if len(res.Errors()) != 4 || res.GetField() != res.GetFields()[0] {
+		t.Fatalf("errors = %v", res.Errors())
+	}
+	if err := v.Binding(&collectForm{Name: "a", Age: 1, Email: "a@b.cn", Addresses: []collectAddress{{Street: "a"}}}).Error(); err != nil {
+		t.Fatalf("valid form error = %v", err)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	res := v.Binding(&collectForm{Name: "abcdef", Age: 1, Email: "a@b.cn", Addresses: []collectAddress{{Street: "a"}, {}}})
+	err := res.Error()
+	var ve ValidationErrors
+	if !errors.As(err, &ve) || len(ve) != 2 {
+		t.Fatalf("error = %#v", err)
+	}
+	want := []FieldError{
+		{Field: "Name", JSONName: "name", Alias: "姓名", Path: "name", StructPath: "Name", Tag: "max", Code: 10008, Param: "5", Value: "abcdef", Message: "姓名长度不超过5个字符"},
+		{Field: "Street", JSONName: "street", Alias: "街道", Path: "addresses[1].street", StructPath: "Addresses[1].Street", Tag: "required", Code: 10001, Value: "", Message: "街道为必填字段"},
+	}
+	for i, fe := range ve {
+		if !reflect.DeepEqual(*fe, want[i]) {
+			t.Fatalf("errors[%d] = %+v, want %+v", i, *fe, want[i])
+		}
+	}
+	if !reflect.DeepEqual(res.Errors(), ve) || err.Error() != "姓名长度不超过5个字符; 街道为必填字段" {
+		t.Fatalf("Errors() = %v", res.Errors())
+	}
+	var fe *FieldError
+	if !errors.As(ve[1], &fe) || fe.Path != "addresses[1].street" {
+		t.Fatalf("FieldError = %+v", fe)
+	}
+}
+
+type skuForm struct {
+	Sku   string `json:"sku" validate:"sku" desc:"商品编码"`
+	Email string `json:"email" validate:"email" desc:"邮箱"`
+}
+
+func TestRegisterValidation(t *testing.T) {
+	hasSkuPrefix := func(tag *Tag) bool {
+		return strings.HasPrefix(tag.GetValue().String(), "SKU")
+	}
+	v := New()
+	if err := v.RegisterValidation("sku", hasSkuPrefix); err != nil {
+		t.Fatal(err)
+	}
+	v.RegisterTranslation("sku", "{0}必须以SKU开头")
+	if err := v.Binding(&skuForm{Sku: "A1", Email: "a@b.cn"}).Error(); err == nil || err.Error() != "商品编码必须以SKU开头" {
+		t.Fatalf("error = %v", err)
+	}
+	if err := v.Binding(&skuForm{Sku: "SKU1", Email: "a@b.cn"}).Error(); err != nil {
+		t.Fatalf("error = %v", err)
+	}
+	// 其他实例未注册 sku
+	other := New()
+	if err := other.Binding(&skuForm{Sku: "SKU1", Email: "a@b.cn"}).Error(); err == nil || err.Error() != "Undefined validation function on field Sku" {
+		t.Fatalf("other instance error = %v", err)
+	}
+
+	// 与内置验证方法重名
+	alwaysValid := func(tag *Tag) bool { return true }
+	if err := v.RegisterValidation("email", alwaysValid); err == nil || err.Error() != "Validation function email is built-in, set override to replace it" {
+		t.Fatalf("clash error = %v", err)
+	}
+	if err := v.Binding(&skuForm{Sku: "SKU1", Email: "x"}).Error(); err == nil || err.Error() != "邮箱必须是一个有效的邮箱" {
+		t.Fatalf("builtin email error = %v", err)
+	}
+	if err := v.RegisterValidation("email", alwaysValid, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Binding(&skuForm{Sku: "SKU1", Email: "x"}).Error(); err != nil {
+		t.Fatalf("overridden email error = %v", err)
+	}
+	if err := other.RegisterValidation("sku", hasSkuPrefix); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Binding(&skuForm{Sku: "SKU1", Email: "x"}).Error(); err == nil {
+		t.Fatal("override leaked to another instance")
+	}
+
+	invalid := map[string]string{
+		"":          "Validation function name cannot be empty",
+		"a,b":       "Validation function name a,b contains reserved characters",
+		"a|b":       "Validation function name a|b contains reserved characters",
+		"a=b":       "Validation function name a=b contains reserved characters",
+		"-":         "Validation function name - contains reserved characters",
+		"omitempty": "Validation function name omitempty contains reserved characters",
+		"a@b":       "Validation function name a@b contains reserved characters",
+		"dive":      "Validation function name dive contains reserved characters",
+		"keys":      "Validation function name keys contains reserved characters",
+		"endkeys":   "Validation function name endkeys contains reserved characters",
+	}
+	for name, want := range invalid {
+		if err := v.RegisterValidation(name, alwaysValid); err == nil || err.Error() != want {
+			t.Fatalf("RegisterValidation(%q) = %v, want %s", name, err, want)
+		}
+	}
+	if err := v.RegisterValidation("nil", nil); err == nil || err.Error() != "Validation function nil cannot be nil" {
+		t.Fatalf("nil func error = %v", err)
+	}
+}
+
+func TestSharedValidator(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				// 每个 goroutine 交替验证通过及失败的数据,结果互不影响
+				form := &collectForm{Name: strings.Repeat("a", (i+j)%8), Age: j % 120, Email: "a@b.cn", Addresses: []collectAddress{{Street: "a"}}}
+				var want []string
+				if form.Name == "" {
+					want = append(want, "姓名为必填字段")
+				} else if len(form.Name) > 5 {
+					want = append(want, "姓名长度不超过5个字符")
+				}
+				if form.Age < 1 {
+					want = append(want, "年龄必须大于或等于1")
+				} else if form.Age > 100 {
+					want = append(want, "年龄必须小于或等于100")
+				}
+				res := v.Binding(form)
+				if got := fmt.Sprint(res.Error()); len(want) == 0 && res.Error() != nil || len(want) > 0 && got != strings.Join(want, "; ") {
+					t.Errorf("Binding(%+v) = %s, want %v", form, got, want)
+					return
+				}
+				// 同一实例按请求选择不同语言
+				if err := v.BindingLocale(&orForm{}, "en-US,en;q=0.9").Error(); fmt.Sprint(err) != "联系方式 is a required field" {
+					t.Errorf("BindingLocale = %v", err)
+					return
+				}
+				data := map[string]interface{}{"name": form.Name, "job": map[string]interface{}{"id": j % 2}}
+				rules := map[string]interface{}{"name": "required", "job": map[string]interface{}{"id": "required"}}
+				wantErrs := 0
+				if form.Name == "" {
+					wantErrs++
+				}
+				if j%2 == 0 {
+					wantErrs++
+				}
+				if res := v.ValidateMap(data, rules); len(res.Errors()) != wantErrs {
+					t.Errorf("ValidateMap(%v) = %v", data, res.Error())
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+type crossPeriod struct {
+	Start int `json:"start"`
+	End   int `json:"end" validate:"gtfield=Start" desc:"结束"`
+	// 当前结构体中不存在时从最外层结构体查找
+	Limit int `json:"limit" validate:"ltefield=Max" desc:"上限"`
+}
+
+type crossForm struct {
+	Max      int         `json:"max"`
+	Password string      `json:"password" desc:"密码"`
+	Confirm  string      `json:"confirm" validate:"eqfield=Password" desc:"确认密码"`
+	Old      string      `json:"old" validate:"nefield=Password" desc:"原密码"`
+	Period   crossPeriod `json:"period" validate:"omitempty"`
+	MinAge   int         `json:"min_age" validate:"ltfield=MaxAge" desc:"最小年龄"`
+	MaxAge   int         `json:"max_age" validate:"gtefield=Period.Start" desc:"最大年龄"`
+}
+
+func TestCrossField(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	valid := crossForm{Max: 10, Password: "a", Confirm: "a", Old: "b", Period: crossPeriod{Start: 1, End: 2, Limit: 10}, MinAge: 1, MaxAge: 2}
+	assertErrors(t, v.Binding(&valid))
+
+	form := valid
+	form.Confirm, form.Old = "b", "a"
+	res := v.Binding(&form)
+	assertErrors(t, res, "confirm:eqfield", "old:nefield")
+	assertMessage(t, res, "确认密码必须等于密码; 原密码不能等于密码")
+	if fe := res.Errors()[0]; fe.Param != "Password" || fe.Value != "b" {
+		t.Fatalf("FieldError = %+v", fe)
+	}
+
+	form = valid
+	form.Period = crossPeriod{Start: 2, End: 2, Limit: 11}
+	form.MinAge, form.MaxAge = 3, 1
+	res = v.Binding(&form)
+	assertErrors(t, res, "period.end:gtfield", "period.limit:ltefield", "min_age:ltfield", "max_age:gtefield")
+	assertMessage(t, res, "结束必须大于start; 上限必须小于或等于max; 最小年龄必须小于最大年龄; 最大年龄必须大于或等于start")
+}
+
+type crossItem struct {
+	Qty int `json:"qty" validate:"ltefield=Stock" desc:"数量"`
+}
+
+type crossSku struct {
+	Stock int         `json:"stock" desc:"库存" desc_en:"stock"`
+	Items []crossItem `json:"items" validate:"dive"`
+}
+
+type crossOrder struct {
+	Stock int      `json:"stock"`
+	Sku   crossSku `json:"sku" validate:"omitempty"`
+}
+
+// 参数字段从字段所在结构体逐级向外查找,使用最近一级的字段
+func TestCrossFieldAncestors(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	order := &crossOrder{Stock: 100, Sku: crossSku{Stock: 2, Items: []crossItem{{Qty: 1}, {Qty: 3}}}}
+	res := v.Binding(order)
+	assertErrors(t, res, "sku.items[1].qty:ltefield")
+	assertMessage(t, res, "数量必须小于或等于库存")
+	assertMessage(t, v.BindingLocale(order, LocaleEn), "数量 must be less than or equal to stock")
+}
+
+type conditionalForm struct {
+	Type    int    `json:"type"`
+	Status  string `json:"status"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	Company string `json:"company" validate:"required_if=Type 2 Status active,max=5" desc:"公司"`
+	Reason  string `json:"reason" validate:"required_unless=Status ok" desc:"原因"`
+	Contact string `json:"contact" validate:"required_without_all=Email Phone" desc:"联系人"`
+	Address string `json:"address" validate:"required_with_all=Email Phone" desc:"地址"`
+	Coupon  string `json:"coupon" validate:"excluded_with=Email" desc:"优惠券"`
+	Remark  string `json:"remark" validate:"excluded_unless=Type 1" desc:"备注"`
+}
+
+func TestConditional(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	// 条件不满足时字段为空不验证后续规则
+	assertErrors(t, v.Binding(&conditionalForm{Type: 2, Status: "ok", Email: "a"}))
+
+	res := v.Binding(&conditionalForm{Type: 2, Status: "active"})
+	assertErrors(t, res, "company:required_if", "reason:required_unless", "contact:required_without_all")
+	assertMessage(t, res, "公司为必填字段; 原因为必填字段; 联系人为必填字段")
+	if fe := res.Errors()[0]; fe.Param != "Type 2 Status active" {
+		t.Fatalf("param = %s", fe.Param)
+	}
+
+	// 条件满足且字段不为空时继续验证后续规则
+	res = v.Binding(&conditionalForm{Type: 2, Status: "active", Company: "abcdef", Reason: "r", Contact: "c"})
+	assertErrors(t, res, "company:max")
+
+	res = v.Binding(&conditionalForm{Type: 2, Status: "ok", Email: "a", Phone: "1", Coupon: "c", Remark: "r"})
+	assertErrors(t, res, "address:required_with_all", "coupon:excluded_with", "remark:excluded_unless")
+	assertMessage(t, res, "地址为必填字段; 优惠券不能填写; 备注不能填写")
+	assertErrors(t, v.Binding(&conditionalForm{Type: 1, Status: "ok", Email: "a", Phone: "1", Address: "x", Remark: "r"}))
+}
+
+type diveAddress struct {
+	Street string `json:"street" validate:"required" desc:"街道"`
+}
+
+type diveForm struct {
+	Tags      []string       `json:"tags" validate:"max=3,dive,required,max=5" desc:"标签"`
+	Credit    []*int         `json:"credit" validate:"dive,required,lte=100" desc:"学分"`
+	Matrix    [][]int        `json:"matrix" validate:"dive,min=1,dive,gt=0" desc:"矩阵"`
+	Scores    map[string]int `json:"scores" validate:"dive,keys,min=2,endkeys,gte=60" desc:"成绩"`
+	Addresses []diveAddress  `json:"addresses" validate:"dive"`
+}
+
+func TestDive(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	n, over := 90, 101
+	assertErrors(t, v.Binding(&diveForm{Tags: []string{"a"}, Credit: []*int{&n}, Matrix: [][]int{{1}}, Scores: map[string]int{"math": 60}}))
+
+	res := v.Binding(&diveForm{
+		Tags:      []string{"a", "", "abcdef"},
+		Credit:    []*int{&n, nil, &over},
+		Matrix:    [][]int{{1}, {}, {1, 0}},
+		Scores:    map[string]int{"math": 59, "a": 60},
+		Addresses: []diveAddress{{Street: "a"}, {}},
+	})
+	assertErrors(t, res,
+		"tags[1]:required", "tags[2]:max",
+		"credit[1]:required", "credit[2]:lte",
+		"matrix[1]:min", "matrix[2][1]:gt",
+		"scores[a]:min", "scores[math]:gte",
+		"addresses[1].street:required")
+	want := []string{"标签为必填字段", "标签长度不超过5个字符", "学分为必填字段", "学分必须小于或等于100"}
+	for i, msg := range want {
+		if fe := res.Errors()[i]; fe.Message != msg {
+			t.Fatalf("errors[%d] = %s, want %s", i, fe.Message, msg)
+		}
+	}
+	// dive 之前的规则作用于切片本身
+	assertErrors(t, v.Binding(&diveForm{Tags: []string{"a", "b", "c", "d"}}), "tags:max")
+}
+
+// 元素为 nil 指针或接口时跳过非必填类验证
+func TestDiveNilElem(t *testing.T) {
+	v := New()
+	v.GetConfig().CollectAll = true
+	n := 101
+	form := &struct {
+		Credit []*int                 `json:"credit" validate:"dive,lte=100" desc:"学分"`
+		Extra  []interface{}          `json:"extra" validate:"dive,max=2" desc:"附加"`
+		Attrs  map[string]interface{} `json:"attrs" validate:"dive,required_with=Name,min=1" desc:"属性"`
+		Name   string                 `json:"name"`
+	}{Credit: []*int{nil, &n}, Extra: []interface{}{nil, "abc"}, Attrs: map[string]interface{}{"a": nil}, Name: "a"}
+	res := v.Binding(form)
+	assertErrors(t, res, "credit[1]:lte", "extra[1]:max", "attrs[a]:required_with")
+	assertMessage(t, res, "学分必须小于或等于100; 附加长度不超过2个字符; 属性为必填字段")
+}
+
+func TestDiveInvalidTag(t *testing.T) {
+	tests := map[string]interface{}{
+		"'endkeys' not found after 'keys' on field Scores": &struct {
+			Scores map[string]int `validate:"dive,keys,min=2"`
+		}{},
+		"'keys' must be immediately preceded by 'dive' on field Scores": &struct {
+			Scores map[string]int `validate:"keys,min=2,endkeys"`
+		}{},
+	}
+	tests["Dive on non collection field Name"] = &struct {
+		Name string `validate:"dive,required"`
+	}{Name: "a"}
+	for want, form := range tests {
+		assertMessage(t, New().Binding(form), want)
+	}
+}
+
+type orForm struct {
+	Contact string   `json:"contact" validate:"required,email|len=11" desc:"联系方式"`
+	Ids     []string `json:"ids" validate:"dive,len=2|len=4" desc:"编号"`
+}
+
+func TestOr(t *testing.T) {
+	v := New()
+	for _, contact := range []string{"a@b.cn", "13800138000"} {
+		assertErrors(t, v.Binding(&orForm{Contact: contact, Ids: []string{"ab", "abcd"}}))
+	}
+	// 之前的规则失败时不验证 | 分隔的规则
+	assertMessage(t, v.Binding(&orForm{}), "联系方式为必填字段")
+
+	res := v.Binding(&orForm{Contact: "abc"})
+	fe := res.Errors()[0]
+	if fe.Tag != "email|len=11" || fe.Param != "" || fe.Value != "abc" {
+		t.Fatalf("FieldError = %+v", fe)
+	}
+	assertMessage(t, res, "联系方式必须是一个有效的邮箱或长度必须是11个字符")
+
+	res = v.Binding(&orForm{Contact: "a@b.cn", Ids: []string{"ab", "abc"}})
+	assertErrors(t, res, "ids[1]:len=2|len=4")
+	assertMessage(t, res, "编号长度必须是2个字符或长度必须是4个字符")
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// Var 验证单个变量,规则语法与 validate tag 一致,alias 为错误信息中的字段别名
+// eg: v.Var(email, "required,email", "邮箱")
+func (v *Validator) Var(field interface{}, tag string, alias ...string) *Result {
+	return v.VarContext(context.Background(), field, tag, alias...)
+}
+
+// VarContext 同 Var,错误信息使用 ctx 中 WithLocale 设置的语言
+func (v *Validator) VarContext(ctx context.Context, field interface{}, tag string, alias ...string) *Result {
+	return v.newValidate(ctx).validateVar(reflect.ValueOf(field), reflect.Value{}, tag, alias)
+}
+
+// VarWithValue 验证单个变量并与 other 比较,跨字段验证方法不需要参数
+// eg: v.VarWithValue(confirmPassword, password, "eqfield", "确认密码")
+func (v *Validator) VarWithValue(field interface{}, other interface{}, tag string, alias ...string) *Result {
+	return v.VarWithValueContext(context.Background(), field, other, tag, alias...)
+}
+
+// VarWithValueContext 同 VarWithValue,错误信息使用 ctx 中 WithLocale 设置的语言
+func (v *Validator) VarWithValueContext(ctx context.Context, field interface{}, other interface{}, tag string, alias ...string) *Result {
+	return v.newValidate(ctx).validateVar(reflect.ValueOf(field), reflect.ValueOf(other), tag, alias)
+}
+
+// validateVar 验证单个变量,other 作为跨字段验证的比较对象
+func (v *validate) validateVar(current, other reflect.Value, tag string, alias []string) *Result {
+	name := blank
+	if len(alias) > 0 {
+		name = alias[0]
+	}
+	rules, err := v.v.extractRuleCache(name, tag, v.scene)
+	if err != nil {
+		return v.res.SetError(err)
+	}
+	typ := interfaceType
+	if current.IsValid() {
+		typ = current.Type()
+	}
+	cf := &cField{
+		name:     name,
+		jsonName: name,
+		alias:    name,
+		sf:       reflect.StructField{Name: name, Type: typ},
+	}
+	v.top = other
+	v.validateRules(current, other, cf, rules, blank, blank)
+	// 跨字段验证未设置参数时,错误信息中的参数显示为比较值
+	if other.IsValid() && other.CanInterface() {
+		for _, field := range v.res.fields {
+			if field.Tags.param == blank && crossFieldFuncS[field.Tags.tag] {
+				field.Tags.param = fmt.Sprint(other.Interface())
+			}
+		}
+	}
+	return v.translateFields()
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVar(t *testing.T) {
+	v := New()
+	assertMessage(t, v.Var("a@b.cn", "required,email", "邮箱"), "")
+	assertMessage(t, v.Var("", "required,email", "邮箱"), "邮箱为必填字段")
+	assertMessage(t, v.Var("x", "required,email", "邮箱"), "邮箱必须是一个有效的邮箱")
+	assertMessage(t, v.Var(0, "omitempty,min=1", "页码"), "")
+	assertMessage(t, v.Var([]string{"a", ""}, "dive,required", "标签"), "标签为必填字段")
+	assertMessage(t, v.Var(time.Second, "gte=2s", "超时"), "超时必须大于或等于2s")
+	assertMessage(t, v.Var("x", "unknown", "a"), "Undefined validation function on field a")
+
+	res := v.Var("13800138000x", "email|len=11", "联系方式")
+	if fe := res.Errors()[0]; fe.Tag != "email|len=11" || fe.Value != "13800138000x" || fe.Path != "" {
+		t.Fatalf("FieldError = %+v", fe)
+	}
+	en := WithLocale(context.Background(), LocaleEn)
+	assertMessage(t, v.VarContext(en, 0, "required,min=1", "page"), "page is a required field")
+}
+
+func TestVarWithValue(t *testing.T) {
+	v := New()
+	assertMessage(t, v.VarWithValue("abc", "abc", "eqfield", "确认密码"), "")
+	// 跨字段验证未设置参数时,错误信息中的参数为比较值
+	assertMessage(t, v.VarWithValue("abd", "abc", "eqfield", "确认密码"), "确认密码必须等于abc")
+	assertMessage(t, v.VarWithValue(1, 2, "gtfield", "结束"), "结束必须大于2")
+}
+
+// 未传 alias 时使用翻译器的主语
+func TestVarSubject(t *testing.T) {
+	v := New()
+	assertMessage(t, v.Var("", "required"), "该值为必填字段")
+	assertMessage(t, v.Var("abc", "email|len=2"), "该值必须是一个有效的邮箱或长度必须是2个字符")
+	en := WithLocale(context.Background(), LocaleEn)
+	assertMessage(t, v.VarContext(en, 3, "max=2"), "Value must be 2 or less")
+	if fe := v.Var("", "required").Errors()[0]; fe.Alias != "" || fe.Field != "" {
+		t.Fatalf("FieldError = %+v", fe)
+	}
+}
+
+func TestRuleCacheBound(t *testing.T) {
+	v := New()
+	for i := 0; i < maxRuleCacheSize+10; i++ {
+		assertMessage(t, v.Var(i, "max="+strconv.Itoa(i-1), "值"), "值必须小于或等于"+strconv.Itoa(i-1))
+	}
+	if size := atomic.LoadInt32(&v.ruleCacheSize); size != maxRuleCacheSize {
+		t.Fatalf("ruleCacheSize = %d, want %d", size, maxRuleCacheSize)
+	}
+	// 注册验证方法后清空缓存
+	if err := v.RegisterValidation("sku", func(*Tag) bool { return true }); err != nil {
+		t.Fatal(err)
+	}
+	if size := atomic.LoadInt32(&v.ruleCacheSize); size != 0 {
+		t.Fatalf("ruleCacheSize = %d, want 0", size)
+	}
+}
@@ -26,6 +26,54 @@ var (
 		"min":      hasMinOf,
 		"max":      hasMaxOf,
 		"oneof":    isOneOf,
+		// 跨字段比较
+		"eqfield":  isEqField,
+		"nefield":  isNeField,
+		"gtfield":  isGtField,
+		"gtefield": isGteField,
+		"ltfield":  isLtField,
+		"ltefield": isLteField,
+		// 条件必填
+		"required_if":          requiredIf,
+		"required_unless":      requiredUnless,
+		"required_with":        requiredWith,
+		"required_with_all":    requiredWithAll,
+		"required_without":     requiredWithout,
+		"required_without_all": requiredWithoutAll,
+		// 条件禁止填写
+		"excluded_if":          excludedIf,
+		"excluded_unless":      excludedUnless,
+		"excluded_with":        excludedWith,
+		"excluded_with_all":    excludedWithAll,
+		"excluded_without":     excludedWithout,
+		"excluded_without_all": excludedWithoutAll,
+	}
+
+	// 跨字段比较验证方法,参数为字段路径,错误信息中显示参数字段的别名
+	crossFieldFuncS = map[string]bool{
+		"eqfield":  true,
+		"nefield":  true,
+		"gtfield":  true,
+		"gtefield": true,
+		"ltfield":  true,
+		"ltefield": true,
+	}
+
+	// 条件验证方法,字段为空时 omitempty 不跳过这些验证
+	// true 表示条件必填:验证通过且字段为空时,跳过后续非条件验证(相当于 omitempty)
+	conditionalFuncS = map[string]bool{
+		"required_if":          true,
+		"required_unless":      true,
+		"required_with":        true,
+		"required_with_all":    true,
+		"required_without":     true,
+		"required_without_all": true,
+		"excluded_if":          false,
+		"excluded_unless":      false,
+		"excluded_with":        false,
+		"excluded_with_all":    false,
+		"excluded_without":     false,
+		"excluded_without_all": false,
 	}
 )
 
@@ -68,27 +116,27 @@ func isEq(tag *Tag) bool {
 		return field.String() == param
 
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(field.Len()) == p
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p := asIntFromType(field.Type(), param)
+		p := tag.asIntFromType(field.Type())
 
 		return field.Int() == p
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		p := asUint(param)
+		p := tag.asUint()
 
 		return field.Uint() == p
 
 	case reflect.Float32, reflect.Float64:
-		p := asFloat(param)
+		p := tag.asFloat()
 
 		return field.Float() == p
 
 	case reflect.Bool:
-		p := asBool(param)
+		p := tag.asBool()
 
 		return field.Bool() == p
 	}
@@ -100,33 +148,31 @@ func isEq(tag *Tag) bool {
 func isLt(tag *Tag) bool {
 
 	field := tag.rv
-	//验证tag对应的值
-	param := tag.param
 
 	switch field.Kind() {
 
 	case reflect.String:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(utf8.RuneCountInString(field.String())) < p
 
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(field.Len()) < p
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p := asIntFromType(field.Type(), param)
+		p := tag.asIntFromType(field.Type())
 
 		return field.Int() < p
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		p := asUint(param)
+		p := tag.asUint()
 
 		return field.Uint() < p
 
 	case reflect.Float32, reflect.Float64:
-		p := asFloat(param)
+		p := tag.asFloat()
 
 		return field.Float() < p
 
@@ -145,33 +191,31 @@ func isLt(tag *Tag) bool {
 func isGt(tag *Tag) bool {
 
 	field := tag.rv
-	//验证tag对应的值
-	param := tag.param
 
 	switch field.Kind() {
 
 	case reflect.String:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(utf8.RuneCountInString(field.String())) > p
 
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(field.Len()) > p
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p := asIntFromType(field.Type(), param)
+		p := tag.asIntFromType(field.Type())
 
 		return field.Int() > p
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		p := asUint(param)
+		p := tag.asUint()
 
 		return field.Uint() > p
 
 	case reflect.Float32, reflect.Float64:
-		p := asFloat(param)
+		p := tag.asFloat()
 
 		return field.Float() > p
 	case reflect.Struct:
@@ -189,33 +233,31 @@ func isGt(tag *Tag) bool {
 func isLte(tag *Tag) bool {
 
 	field := tag.rv
-	//验证tag对应的值
-	param := tag.param
 
 	switch field.Kind() {
 
 	case reflect.String:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(utf8.RuneCountInString(field.String())) <= p
 
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(field.Len()) <= p
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p := asIntFromType(field.Type(), param)
+		p := tag.asIntFromType(field.Type())
 
 		return field.Int() <= p
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		p := asUint(param)
+		p := tag.asUint()
 
 		return field.Uint() <= p
 
 	case reflect.Float32, reflect.Float64:
-		p := asFloat(param)
+		p := tag.asFloat()
 
 		return field.Float() <= p
 
@@ -237,33 +279,31 @@ func isLte(tag *Tag) bool {
 func isGte(tag *Tag) bool {
 
 	field := tag.rv
-	//验证tag对应的值
-	param := tag.param
 
 	switch field.Kind() {
 
 	case reflect.String:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(utf8.RuneCountInString(field.String())) >= p
 
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(field.Len()) >= p
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p := asIntFromType(field.Type(), param)
+		p := tag.asIntFromType(field.Type())
 
 		return field.Int() >= p
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		p := asUint(param)
+		p := tag.asUint()
 
 		return field.Uint() >= p
 
 	case reflect.Float32, reflect.Float64:
-		p := asFloat(param)
+		p := tag.asFloat()
 
 		return field.Float() >= p
 
@@ -283,12 +323,26 @@ func isGte(tag *Tag) bool {
 
 // hasValue
 func hasValue(tag *Tag) bool {
-	field := tag.rv
+	if tag.present != nil {
+		return *tag.present
+	}
+	return !isZeroValue(*tag.rv)
+}
+
+// isZeroValue 字段是否为空值
+func isZeroValue(field reflect.Value) bool {
 	switch field.Kind() {
 	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
-		return !field.IsNil()
+		return field.IsNil()
 	default:
-		return field.IsValid() && field.Interface() != reflect.Zero(field.Type()).Interface()
+		if !field.IsValid() {
+			return true
+		}
+		// 不可比较的类型 eg: 包含切片的结构体
+		if !field.Type().Comparable() {
+			return field.IsZero()
+		}
+		return field.Interface() == reflect.Zero(field.Type()).Interface()
 	}
 }
 
@@ -296,33 +350,31 @@ func hasValue(tag *Tag) bool {
 func hasLengthOf(tag *Tag) bool {
 
 	field := tag.rv
-	//验证tag对应的值
-	param := tag.param
 
 	switch field.Kind() {
 
 	case reflect.String:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(utf8.RuneCountInString(field.String())) == p
 
 	case reflect.Slice, reflect.Map, reflect.Array:
-		p := asInt(param)
+		p := tag.asInt()
 
 		return int64(field.Len()) == p
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		p := asIntFromType(field.Type(), param)
+		p := tag.asIntFromType(field.Type())
 
 		return field.Int() == p
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-		p := asUint(param)
+		p := tag.asUint()
 
 		return field.Uint() == p
 
 	case reflect.Float32, reflect.Float64:
-		p := asFloat(param)
+		p := tag.asFloat()
 
 		return field.Float() == p
 	}
@@ -339,18 +391,24 @@ func parseOneOfParam2(s string) []string {
 	oneofValsCacheRWLock.RUnlock()
 	if !ok {
 		oneofValsCacheRWLock.Lock()
-		vals = splitParamsRegex.FindAllString(s, -1)
-		for i := 0; i < len(vals); i++ {
-			vals[i] = strings.Replace(vals[i], "'", "", -1)
-		}
+		vals = splitOneOfParam(s)
 		oneofValsCache[s] = vals
 		oneofValsCacheRWLock.Unlock()
 	}
 	return vals
 }
 
+// splitOneOfParam 按空格分隔 oneof 参数,单引号内的空格不分隔 eg: 'a b' c
+func splitOneOfParam(s string) []string {
+	vals := splitParamsRegex.FindAllString(s, -1)
+	for i := 0; i < len(vals); i++ {
+		vals[i] = strings.Replace(vals[i], "'", "", -1)
+	}
+	return vals
+}
+
 func isOneOf(tag *Tag) bool {
-	vals := parseOneOfParam2(tag.param)
+	vals := tag.oneOfVals()
 	field := tag.rv
 	//验证tag对应的值
 	var v string
@@ -371,3 +429,296 @@ func isOneOf(tag *Tag) bool {
 	}
 	return false
 }
+
+// isEqField
+func isEqField(tag *Tag) bool {
+	field := tag.rv
+	other, ok := tag.LookupField(tag.param)
+	if !ok {
+		return false
+	}
+	// 字符串比较内容,其他类型与 gtfield 等规则保持一致
+	if field.Kind() == reflect.String && other.Kind() == reflect.String {
+		return field.String() == other.String()
+	}
+	if field.Kind() == reflect.Bool && other.Kind() == reflect.Bool {
+		return field.Bool() == other.Bool()
+	}
+	c, ok := compareField(*field, other)
+	return ok && c == 0
+}
+
+// isNeField
+func isNeField(tag *Tag) bool {
+	if _, ok := tag.LookupField(tag.param); !ok {
+		return false
+	}
+	return !isEqField(tag)
+}
+
+// isGtField
+func isGtField(tag *Tag) bool {
+	other, ok := tag.LookupField(tag.param)
+	if !ok {
+		return false
+	}
+	c, ok := compareField(*tag.rv, other)
+	return ok && c > 0
+}
+
+// isGteField
+func isGteField(tag *Tag) bool {
+	other, ok := tag.LookupField(tag.param)
+	if !ok {
+		return false
+	}
+	c, ok := compareField(*tag.rv, other)
+	return ok && c >= 0
+}
+
+// isLtField
+func isLtField(tag *Tag) bool {
+	other, ok := tag.LookupField(tag.param)
+	if !ok {
+		return false
+	}
+	c, ok := compareField(*tag.rv, other)
+	return ok && c < 0
+}
+
+// isLteField
+func isLteField(tag *Tag) bool {
+	other, ok := tag.LookupField(tag.param)
+	if !ok {
+		return false
+	}
+	c, ok := compareField(*tag.rv, other)
+	return ok && c <= 0
+}
+
+// compareField 比较两个字段的值,field 小于、等于、大于 other 时分别返回 -1、0、1
+// 数值比较大小,字符串比较字符个数,切片、数组、map 比较长度, time.Time 比较时间
+// 两个字段类型无法比较时返回 false
+func compareField(field, other reflect.Value) (int, bool) {
+	switch field.Kind() {
+	case reflect.String:
+		if other.Kind() != reflect.String {
+			return 0, false
+		}
+		return compareInt(int64(utf8.RuneCountInString(field.String())), int64(utf8.RuneCountInString(other.String()))), true
+
+	case reflect.Slice, reflect.Map, reflect.Array:
+		switch other.Kind() {
+		case reflect.Slice, reflect.Map, reflect.Array:
+			return compareInt(int64(field.Len()), int64(other.Len())), true
+		}
+		return 0, false
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch other.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return compareInt(field.Int(), other.Int()), true
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch other.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return compareUint(field.Uint(), other.Uint()), true
+		}
+
+	case reflect.Struct:
+		if field.Type() == timeType && other.Type() == timeType {
+			t := field.Interface().(time.Time)
+			o := other.Interface().(time.Time)
+			switch {
+			case t.Before(o):
+				return -1, true
+			case t.After(o):
+				return 1, true
+			}
+			return 0, true
+		}
+		return 0, false
+	}
+
+	// 不同类型的数值统一转换为 float64 比较
+	f, fok := asFloatValue(field)
+	o, ook := asFloatValue(other)
+	if !fok || !ook {
+		return 0, false
+	}
+	switch {
+	case f < o:
+		return -1, true
+	case f > o:
+		return 1, true
+	}
+	return 0, true
+}
+
+// asFloatValue 数值类型字段转换为 float64
+func asFloatValue(field reflect.Value) (float64, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(field.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(field.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return field.Float(), true
+	}
+	return 0, false
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// requiredIf 参数中全部字段等于指定值时必填 eg: required_if=ContactType 2
+func requiredIf(tag *Tag) bool {
+	return !matchFieldValues(tag, true) || hasValue(tag)
+}
+
+// requiredUnless 参数中任一字段等于指定值时不必填,否则必填 eg: required_unless=ContactType 1
+func requiredUnless(tag *Tag) bool {
+	return matchFieldValues(tag, false) || hasValue(tag)
+}
+
+// requiredWith 参数中任一字段有值时必填 eg: required_with=Phone Email
+func requiredWith(tag *Tag) bool {
+	return !hasFieldValues(tag, false) || hasValue(tag)
+}
+
+// requiredWithAll 参数中全部字段有值时必填
+func requiredWithAll(tag *Tag) bool {
+	return !hasFieldValues(tag, true) || hasValue(tag)
+}
+
+// requiredWithout 参数中任一字段为空时必填
+func requiredWithout(tag *Tag) bool {
+	return !lackFieldValues(tag, false) || hasValue(tag)
+}
+
+// requiredWithoutAll 参数中全部字段为空时必填 eg: required_without_all=Phone Email
+func requiredWithoutAll(tag *Tag) bool {
+	return !lackFieldValues(tag, true) || hasValue(tag)
+}
+
+// excludedIf 参数中全部字段等于指定值时不能填写
+func excludedIf(tag *Tag) bool {
+	return !matchFieldValues(tag, true) || !hasValue(tag)
+}
+
+// excludedUnless 参数中任一字段等于指定值时可以填写,否则不能填写
+func excludedUnless(tag *Tag) bool {
+	return matchFieldValues(tag, false) || !hasValue(tag)
+}
+
+// excludedWith 参数中任一字段有值时不能填写
+func excludedWith(tag *Tag) bool {
+	return !hasFieldValues(tag, false) || !hasValue(tag)
+}
+
+// excludedWithAll 参数中全部字段有值时不能填写
+func excludedWithAll(tag *Tag) bool {
+	return !hasFieldValues(tag, true) || !hasValue(tag)
+}
+
+// excludedWithout 参数中任一字段为空时不能填写
+func excludedWithout(tag *Tag) bool {
+	return !lackFieldValues(tag, false) || !hasValue(tag)
+}
+
+// excludedWithoutAll 参数中全部字段为空时不能填写
+func excludedWithoutAll(tag *Tag) bool {
+	return !lackFieldValues(tag, true) || !hasValue(tag)
+}
+
+// matchFieldValues 参数为 "字段 值" 成对出现,all 为 true 时全部匹配返回 true,否则任一匹配返回 true
+func matchFieldValues(tag *Tag, all bool) bool {
+	params := tag.oneOfVals()
+	if len(params)%2 != 0 {
+		panic(fmt.Sprintf("Bad param number for %s %s", tag.tag, tag.param))
+	}
+	for i := 0; i < len(params); i += 2 {
+		matched := fieldValueEq(tag, params[i], params[i+1])
+		if all && !matched {
+			return false
+		}
+		if !all && matched {
+			return true
+		}
+	}
+	return all
+}
+
+// hasFieldValues 参数中的字段是否有值,all 为 true 时要求全部有值,否则任一有值
+// BindingJSON 时按 json 中是否存在该字段判断
+func hasFieldValues(tag *Tag, all bool) bool {
+	for _, name := range tag.oneOfVals() {
+		present := tag.hasField(name)
+		if all && !present {
+			return false
+		}
+		if !all && present {
+			return true
+		}
+	}
+	return all
+}
+
+// lackFieldValues 参数中的字段是否为空,all 为 true 时要求全部为空,否则任一为空
+// BindingJSON 时按 json 中是否存在该字段判断
+func lackFieldValues(tag *Tag, all bool) bool {
+	for _, name := range tag.oneOfVals() {
+		absent := !tag.hasField(name)
+		if all && !absent {
+			return false
+		}
+		if !all && absent {
+			return true
+		}
+	}
+	return all
+}
+
+// fieldValueEq 指定字段的值是否等于 value,切片、数组、map 比较长度
+func fieldValueEq(tag *Tag, name, value string) bool {
+	field, ok := tag.LookupField(name)
+	if !ok {
+		return false
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return field.String() == value
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return int64(field.Len()) == asInt(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() == asIntFromType(field.Type(), value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return field.Uint() == asUint(value)
+	case reflect.Float32, reflect.Float64:
+		return field.Float() == asFloat(value)
+	case reflect.Bool:
+		return field.Bool() == asBool(value)
+	case reflect.Ptr, reflect.Interface:
+		// nil 指针或接口不等于任何值
+		return false
+	}
+	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+}